@@ -0,0 +1,239 @@
+package zabbix
+
+import "encoding/json"
+
+type (
+	EventSource       int
+	ActionStatus      int
+	EvalType          int
+	ConditionType     int
+	ConditionOperator int
+	OperationType     int
+)
+
+const (
+	EventSourceTrigger          EventSource = 0
+	EventSourceDiscovery        EventSource = 1
+	EventSourceAutoregistration EventSource = 2
+	EventSourceInternal         EventSource = 3
+	EventSourceService          EventSource = 4 // Zabbix 6.0+
+)
+
+const (
+	ActionEnabled  ActionStatus = 0
+	ActionDisabled ActionStatus = 1
+)
+
+const (
+	EvalAndOr  EvalType = 0
+	EvalAnd    EvalType = 1
+	EvalOr     EvalType = 2
+	EvalCustom EvalType = 3 // use Formula with FormulaId of conditions, like "A and (B or C)"
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/action/object#action-filter-condition
+// Event sources supporting condition are listed in comments.
+const (
+	ConditionHostGroup             ConditionType = 0  // trigger, discovery, internal
+	ConditionHost                  ConditionType = 1  // trigger, internal
+	ConditionTrigger               ConditionType = 2  // trigger
+	ConditionEventName             ConditionType = 3  // trigger, trigger name before Zabbix 6.0
+	ConditionTriggerSeverity       ConditionType = 4  // trigger
+	ConditionTimePeriod            ConditionType = 6  // trigger
+	ConditionHostIP                ConditionType = 7  // discovery
+	ConditionDiscoveredServiceType ConditionType = 8  // discovery
+	ConditionDiscoveredServicePort ConditionType = 9  // discovery
+	ConditionDiscoveryStatus       ConditionType = 10 // discovery
+	ConditionUptimeDowntime        ConditionType = 11 // discovery
+	ConditionReceivedValue         ConditionType = 12 // discovery
+	ConditionHostTemplate          ConditionType = 13 // trigger, internal
+	ConditionEventAcknowledged     ConditionType = 14 // operation conditions only
+	ConditionProblemSuppressed     ConditionType = 16 // trigger
+	ConditionDiscoveryRule         ConditionType = 18 // discovery
+	ConditionDiscoveryCheck        ConditionType = 19 // discovery
+	ConditionProxy                 ConditionType = 20 // discovery, autoregistration
+	ConditionDiscoveryObject       ConditionType = 21 // discovery
+	ConditionHostName              ConditionType = 22 // autoregistration
+	ConditionEventType             ConditionType = 23 // internal
+	ConditionHostMetadata          ConditionType = 24 // autoregistration
+	ConditionEventTag              ConditionType = 25 // trigger, internal, service
+	ConditionEventTagValue         ConditionType = 26 // trigger, internal, service
+	ConditionService               ConditionType = 27 // service
+	ConditionServiceName           ConditionType = 28 // service
+)
+
+const (
+	OperatorEquals         ConditionOperator = 0
+	OperatorNotEquals      ConditionOperator = 1
+	OperatorContains       ConditionOperator = 2
+	OperatorNotContains    ConditionOperator = 3
+	OperatorIn             ConditionOperator = 4
+	OperatorGreaterOrEqual ConditionOperator = 5
+	OperatorLessOrEqual    ConditionOperator = 6
+	OperatorNotIn          ConditionOperator = 7
+	OperatorMatches        ConditionOperator = 8
+	OperatorNotMatches     ConditionOperator = 9
+	OperatorYes            ConditionOperator = 10
+	OperatorNo             ConditionOperator = 11
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/action/object#action-operation
+const (
+	OperationSendMessage         OperationType = 0
+	OperationRemoteCommand       OperationType = 1
+	OperationAddHost             OperationType = 2
+	OperationRemoveHost          OperationType = 3
+	OperationAddToHostGroup      OperationType = 4
+	OperationRemoveFromHostGroup OperationType = 5
+	OperationLinkTemplate        OperationType = 6
+	OperationUnlinkTemplate      OperationType = 7
+	OperationEnableHost          OperationType = 8
+	OperationDisableHost         OperationType = 9
+	OperationSetInventoryMode    OperationType = 10
+	OperationRecoveryNotifyAll   OperationType = 11 // recovery operations only
+	OperationUpdateNotifyAll     OperationType = 12 // update operations only
+)
+
+// Filter condition, Value depends on ConditionType: Id of host group for ConditionHostGroup,
+// severity for ConditionTriggerSeverity, tag name for ConditionEventTagValue with tag value in Value2, etc.
+type ActionCondition struct {
+	ConditionId   string            `json:"conditionid,omitempty" zabbix:"readonly"`
+	ConditionType ConditionType     `json:"conditiontype"`
+	Operator      ConditionOperator `json:"operator"`
+	Value         string            `json:"value"`
+	Value2        string            `json:"value2,omitempty"`
+	FormulaId     string            `json:"formulaid,omitempty"` // for EvalCustom
+}
+
+type ActionConditions []ActionCondition
+
+type ActionFilter struct {
+	EvalType    EvalType         `json:"evaltype"`
+	Formula     string           `json:"formula,omitempty"` // for EvalCustom
+	EvalFormula string           `json:"eval_formula,omitempty" zabbix:"readonly"`
+	Conditions  ActionConditions `json:"conditions"`
+}
+
+// Sends nil Conditions as empty list, Zabbix rejects null.
+func (f ActionFilter) MarshalJSON() ([]byte, error) {
+	type actionFilter ActionFilter
+	v := actionFilter(f)
+	if v.Conditions == nil {
+		v.Conditions = ActionConditions{}
+	}
+	return json.Marshal(v)
+}
+
+// Message of OperationSendMessage and notify operations.
+type OpMessage struct {
+	DefaultMsg  int    `json:"default_msg"` // 1 to use message template of media type, 0 to use Subject and Message
+	Subject     string `json:"subject,omitempty"`
+	Message     string `json:"message,omitempty"`
+	MediaTypeId string `json:"mediatypeid,omitempty"` // empty or "0" to send to all media types
+}
+
+// Script of OperationRemoteCommand. Zabbix 5.4+ runs global scripts by ScriptId only,
+// other fields are used by earlier versions.
+type OpCommand struct {
+	ScriptId  string `json:"scriptid,omitempty"`
+	Type      int    `json:"type,omitempty"`
+	Command   string `json:"command,omitempty"`
+	ExecuteOn int    `json:"execute_on,omitempty"`
+}
+
+// Operation condition, the only supported type is ConditionEventAcknowledged with Value "0" or "1".
+type OpCondition struct {
+	OpConditionId string            `json:"opconditionid,omitempty" zabbix:"readonly"`
+	ConditionType ConditionType     `json:"conditiontype"`
+	Operator      ConditionOperator `json:"operator"`
+	Value         string            `json:"value"`
+}
+
+type OpInventory struct {
+	InventoryMode InventoryMode `json:"inventory_mode"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/action/object#action-operation
+// Recovery and update operations use the same type without escalation fields.
+type ActionOperation struct {
+	OperationId   string        `json:"operationid,omitempty" zabbix:"readonly"`
+	OperationType OperationType `json:"operationtype"`
+
+	// Escalation steps, EscStepTo 0 means infinitely. EscPeriod "0" uses default action period.
+	EscPeriod   string   `json:"esc_period,omitempty"`
+	EscStepFrom int      `json:"esc_step_from,omitempty"`
+	EscStepTo   int      `json:"esc_step_to,omitempty"`
+	EvalType    EvalType `json:"evaltype,omitempty"` // for OpConditions, EvalAndOr or EvalAnd
+
+	OpMessage       *OpMessage    `json:"opmessage,omitempty"`
+	OpMessageGroups UserGroupIds  `json:"opmessage_grp,omitempty"`
+	OpMessageUsers  UserIds       `json:"opmessage_usr,omitempty"`
+	OpCommand       *OpCommand    `json:"opcommand,omitempty"`
+	OpCommandGroups HostGroupIds  `json:"opcommand_grp,omitempty"`
+	OpCommandHosts  HostIds       `json:"opcommand_hst,omitempty"` // HostId "0" is current host
+	OpConditions    []OpCondition `json:"opconditions,omitempty"`
+	OpGroups        HostGroupIds  `json:"opgroup,omitempty"`
+	OpTemplates     TemplateIds   `json:"optemplate,omitempty"`
+	OpInventory     *OpInventory  `json:"opinventory,omitempty"`
+}
+
+type ActionOperations []ActionOperation
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/action/object
+type Action struct {
+	ActionId    string       `json:"actionid,omitempty"`
+	Name        string       `json:"name"`
+	EventSource EventSource  `json:"eventsource"`
+	Status      ActionStatus `json:"status,omitempty"`
+	EscPeriod   string       `json:"esc_period,omitempty"` // default operation step duration, e.g. "1h"
+
+	// Use "selectFilter", "selectOperations", "selectRecoveryOperations" and "selectUpdateOperations" to get them.
+	Filter             *ActionFilter    `json:"filter,omitempty"`
+	Operations         ActionOperations `json:"operations,omitempty"`
+	RecoveryOperations ActionOperations `json:"recovery_operations,omitempty"`
+	UpdateOperations   ActionOperations `json:"update_operations,omitempty"` // Zabbix 4.0+
+}
+
+type Actions []Action
+
+func (a *Action) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "action", IdField: "actionid"} }
+func (a *Action) ObjectId() string       { return a.ActionId }
+func (a *Action) SetObjectId(id string)  { a.ActionId = id }
+
+// Wrapper for action.get: https://www.zabbix.com/documentation/current/manual/api/reference/action/get
+func (api *API) ActionsGet(params Params) (res Actions, err error) {
+	return GetObjects[Action](api, params)
+}
+
+// Gets action by Id only if there is exactly 1 matching action. Filter and all operations are selected.
+func (api *API) ActionGetById(id string) (res *Action, err error) {
+	return GetObject[Action](api, Params{
+		"actionids":                id,
+		"selectFilter":             "extend",
+		"selectOperations":         "extend",
+		"selectRecoveryOperations": "extend",
+		"selectUpdateOperations":   "extend",
+	})
+}
+
+// Wrapper for action.create: https://www.zabbix.com/documentation/current/manual/api/reference/action/create
+func (api *API) ActionsCreate(actions Actions) (err error) {
+	return CreateObjects[Action](api, actions)
+}
+
+// Wrapper for action.update: https://www.zabbix.com/documentation/current/manual/api/reference/action/update
+// Non-empty Filter and operations replace existing ones.
+func (api *API) ActionsUpdate(actions Actions, fields ...string) (err error) {
+	return UpdateObjects[Action](api, actions, fields...)
+}
+
+// Wrapper for action.delete: https://www.zabbix.com/documentation/current/manual/api/reference/action/delete
+// Cleans ActionId in all actions elements if call succeed.
+func (api *API) ActionsDelete(actions Actions) (err error) {
+	return DeleteObjects[Action](api, actions)
+}
+
+// Wrapper for action.delete: https://www.zabbix.com/documentation/current/manual/api/reference/action/delete
+func (api *API) ActionsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Action](api, ids)
+}
@@ -0,0 +1,128 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestActionCreate(t *testing.T) {
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"action.create": func(raw json.RawMessage) interface{} {
+			var p []map[string]interface{}
+			if err := json.Unmarshal(raw, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			var expected []map[string]interface{}
+			if err := json.Unmarshal([]byte(`[{
+				"name": "Notify ops", "eventsource": 0, "esc_period": "1h",
+				"filter": {"evaltype": 3, "formula": "A and B", "conditions": [
+					{"conditiontype": 0, "operator": 0, "value": "2", "formulaid": "A"},
+					{"conditiontype": 4, "operator": 5, "value": "4", "formulaid": "B"}
+				]},
+				"operations": [{
+					"operationtype": 0, "esc_step_from": 1, "esc_step_to": 2,
+					"opmessage": {"default_msg": 1, "mediatypeid": "1"},
+					"opmessage_grp": [{"usrgrpid": "7"}]
+				}],
+				"recovery_operations": [{"operationtype": 11, "opmessage": {"default_msg": 1}}]
+			}]`), &expected); err != nil {
+				t.Error(err)
+				return nil
+			}
+			if !reflect.DeepEqual(p, expected) {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return map[string]interface{}{"actionids": []string{"3"}}
+		},
+	})
+
+	actions := Actions{{
+		Name:        "Notify ops",
+		EventSource: EventSourceTrigger,
+		EscPeriod:   "1h",
+		Filter: &ActionFilter{
+			EvalType:    EvalCustom,
+			Formula:     "A and B",
+			EvalFormula: "A and B", // read-only, not sent
+			Conditions: ActionConditions{
+				{ConditionId: "1", ConditionType: ConditionHostGroup, Operator: OperatorEquals, Value: "2", FormulaId: "A"},
+				{ConditionType: ConditionTriggerSeverity, Operator: OperatorGreaterOrEqual, Value: "4", FormulaId: "B"},
+			},
+		},
+		Operations: ActionOperations{{
+			OperationId:     "5",
+			OperationType:   OperationSendMessage,
+			EscStepFrom:     1,
+			EscStepTo:       2,
+			OpMessage:       &OpMessage{DefaultMsg: 1, MediaTypeId: "1"},
+			OpMessageGroups: UserGroupIds{{UserGroupId: "7"}},
+		}},
+		RecoveryOperations: ActionOperations{{OperationType: OperationRecoveryNotifyAll, OpMessage: &OpMessage{DefaultMsg: 1}}},
+	}}
+	if err := api.ActionsCreate(actions); err != nil {
+		t.Fatal(err)
+	}
+	if actions[0].ActionId != "3" {
+		t.Errorf("unexpected Id %q", actions[0].ActionId)
+	}
+}
+
+func TestActionUpdateEmptyFilter(t *testing.T) {
+	expected := `[{"actionid":"3","filter":{"conditions":[],"evaltype":0}}]`
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"action.update": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"actionids": []string{"3"}}
+		},
+	})
+	if err := api.ActionsUpdate(Actions{{ActionId: "3", Filter: &ActionFilter{}}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActionDecode(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"action.get": func(raw json.RawMessage) interface{} {
+			return []map[string]interface{}{{
+				"actionid": "3", "name": "Autoregister", "eventsource": "2", "status": "0", "esc_period": "1h",
+				"filter": map[string]interface{}{"evaltype": "0", "formula": "", "eval_formula": "A", "conditions": []map[string]string{
+					{"conditionid": "9", "conditiontype": "24", "operator": "2", "value": "linux", "value2": "", "formulaid": "A"},
+				}},
+				"operations": []map[string]interface{}{
+					{"operationid": "10", "operationtype": "4", "esc_step_from": "1", "esc_step_to": "1", "evaltype": "0",
+						"opgroup": []map[string]string{{"groupid": "2"}}, "opconditions": []interface{}{}},
+					{"operationid": "11", "operationtype": "10", "opinventory": map[string]string{"inventory_mode": "1"}},
+				},
+				"recovery_operations": []interface{}{},
+				"update_operations":   []interface{}{},
+			}}
+		},
+	})
+
+	a, err := api.ActionGetById("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Action{
+		ActionId: "3", Name: "Autoregister", EventSource: EventSourceAutoregistration, EscPeriod: "1h",
+		Filter: &ActionFilter{EvalFormula: "A", Conditions: ActionConditions{
+			{ConditionId: "9", ConditionType: ConditionHostMetadata, Operator: OperatorContains, Value: "linux", FormulaId: "A"},
+		}},
+		Operations: ActionOperations{
+			{OperationId: "10", OperationType: OperationAddToHostGroup, EscStepFrom: 1, EscStepTo: 1,
+				OpGroups: HostGroupIds{{GroupId: "2"}}, OpConditions: []OpCondition{}},
+			{OperationId: "11", OperationType: OperationSetInventoryMode, OpInventory: &OpInventory{InventoryMode: InventoryAutomatic}},
+		},
+		RecoveryOperations: ActionOperations{},
+		UpdateOperations:   ActionOperations{},
+	}
+	if !reflect.DeepEqual(a, expected) {
+		t.Errorf("expected %+v, got %+v", expected, a)
+	}
+}
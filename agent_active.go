@@ -0,0 +1,144 @@
+package zabbix
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+)
+
+// Protocol version reported by AgentClient by default.
+const AgentProtocolVersion = "7.0.0"
+
+// Item to be checked by active agent.
+type ActiveCheck struct {
+	Key         string `json:"key"`
+	ItemId      uint64 `json:"itemid,omitempty"` // Zabbix 6.4+
+	Delay       string `json:"delay"`            // e.g. "30s", "1m" or scheduling intervals
+	LastLogSize int64  `json:"lastlogsize"`
+	MTime       int64  `json:"mtime"`
+	Timeout     string `json:"timeout,omitempty"` // Zabbix 7.0+
+}
+
+// Response of server or proxy to "active checks" request.
+type ActiveChecks struct {
+	Revision int64         `json:"config_revision,omitempty"` // Zabbix 6.4+
+	Checks   []ActiveCheck `json:"data"`                      // empty if not changed since revision
+}
+
+// AgentClient performs handshake of active agent with server or proxy: gets list of active checks,
+// creating host by autoregistration action if it doesn't exist yet. Values can be sent with Sender.
+type AgentClient struct {
+	Server        string        // Zabbix server or proxy address
+	Port          int           // Zabbix server or proxy port (default: 10051)
+	Host          string        // host name
+	HostMetadata  string        // matched by autoregistration action conditions
+	ListenIP      string        // IP address of host interface, address of connection if empty
+	ListenDNS     string        // DNS name of host interface
+	ListenPort    int           // port of host interface (default: 10050)
+	Version       string        // protocol version (default: AgentProtocolVersion)
+	Session       string        // agent session token, random by default
+	Timeout       time.Duration // connection timeout (default: 5 seconds)
+	RetryInterval time.Duration // interval of Register retries (default: 5 seconds)
+	TLSConfig     *tls.Config   // certificate-based connection, nil for unencrypted
+	Logger        *log.Logger   // Logger for debugging
+
+	// Last received configuration revision, sent with next "active checks" request to get only changes.
+	ConfigRevision int64
+}
+
+// NewAgentClient creates a new AgentClient instance
+func NewAgentClient(server string, port int, host string) *AgentClient {
+	if port == 0 {
+		port = 10051
+	}
+	session := make([]byte, 16)
+	rand.Read(session)
+	return &AgentClient{
+		Server:        server,
+		Port:          port,
+		Host:          host,
+		ListenPort:    10050,
+		Version:       AgentProtocolVersion,
+		Session:       hex.EncodeToString(session),
+		Timeout:       5 * time.Second,
+		RetryInterval: 5 * time.Second,
+	}
+}
+
+func (c *AgentClient) printf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+// ActiveChecks sends "active checks" request. If host doesn't exist, server starts autoregistration
+// and returns *ProtocolError until host is created.
+func (c *AgentClient) ActiveChecks() (res *ActiveChecks, err error) {
+	req := map[string]interface{}{
+		"request": "active checks",
+		"host":    c.Host,
+		"version": c.Version,
+		"variant": 2, // Zabbix agent 2
+		"session": c.Session,
+	}
+	if c.HostMetadata != "" {
+		req["host_metadata"] = c.HostMetadata
+	}
+	if c.ListenIP != "" {
+		req["ip"] = c.ListenIP
+	}
+	if c.ListenDNS != "" {
+		req["interface"] = c.ListenDNS
+	}
+	if c.ListenPort != 0 {
+		req["port"] = c.ListenPort
+	}
+	if c.ConfigRevision != 0 {
+		req["config_revision"] = c.ConfigRevision
+	}
+
+	conn := &zbxdConn{
+		address:   net.JoinHostPort(c.Server, fmt.Sprintf("%d", c.Port)),
+		timeout:   c.Timeout,
+		tlsConfig: c.TLSConfig,
+		printf:    c.printf,
+	}
+	res = new(ActiveChecks)
+	if err = conn.exchange("active checks", req, res); err != nil {
+		return nil, err
+	}
+	if res.Revision != 0 {
+		c.ConfigRevision = res.Revision
+	}
+	return
+}
+
+// Register repeats ActiveChecks every RetryInterval while server responds with *ProtocolError,
+// e.g. while autoregistration action creates host, but no longer than timeout.
+// Returns last error if host is not registered in time.
+func (c *AgentClient) Register(timeout time.Duration) (res *ActiveChecks, err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		res, err = c.ActiveChecks()
+		var e *ProtocolError
+		if err == nil || !errors.As(err, &e) || time.Now().Add(c.RetryInterval).After(deadline) {
+			return
+		}
+		c.printf("Host %s is not registered yet: %s", c.Host, e.Info)
+		time.Sleep(c.RetryInterval)
+	}
+}
+
+// Sender returns Sender for values of active checks, connecting to the same server or proxy with the same TLSConfig.
+func (c *AgentClient) Sender() *Sender {
+	s := NewSender(c.Server, c.Port)
+	s.Timeout = c.Timeout
+	s.TLSConfig = c.TLSConfig
+	s.Logger = c.Logger
+	return s
+}
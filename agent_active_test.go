@@ -0,0 +1,80 @@
+package zabbix_test
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestAgentClientRegister(t *testing.T) {
+	var requests int
+	host, port := newFakeZBXD(t, func(flags byte, req map[string]interface{}) interface{} {
+		requests++
+		for k, v := range map[string]interface{}{
+			"request": "active checks", "host": "vm1", "host_metadata": "Linux web",
+			"ip": "10.0.0.7", "port": json.Number("10050"),
+		} {
+			if req[k] != v {
+				t.Errorf("expected %s %v, got %v", k, v, req[k])
+			}
+		}
+		if requests < 3 {
+			return map[string]interface{}{"response": "failed", "info": "host [vm1] not found"}
+		}
+		return map[string]interface{}{
+			"response": "success", "config_revision": 5,
+			"data": []map[string]interface{}{{"key": "agent.ping", "itemid": 100, "delay": "1m", "lastlogsize": 0, "mtime": 0}},
+		}
+	})
+
+	c := NewAgentClient(host, port, "vm1")
+	c.HostMetadata, c.ListenIP, c.RetryInterval = "Linux web", "10.0.0.7", time.Millisecond
+	if _, err := c.Register(0); err == nil {
+		t.Fatal("expected error without retries")
+	}
+
+	checks, err := c.Register(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ActiveCheck{{Key: "agent.ping", ItemId: 100, Delay: "1m"}}
+	if len(checks.Checks) != 1 || checks.Checks[0] != expected[0] || c.ConfigRevision != 5 {
+		t.Errorf("unexpected checks %+v, revision %d", checks, c.ConfigRevision)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestAgentClientSenderTLS(t *testing.T) {
+	// unencrypted server closing connections, so TLS handshake fails
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	c := NewAgentClient("127.0.0.1", l.Addr().(*net.TCPAddr).Port, "vm1")
+	c.TLSConfig = &tls.Config{ServerName: "zabbix"}
+	s := c.Sender()
+	if s.TLSConfig != c.TLSConfig || s.Timeout != c.Timeout {
+		t.Errorf("unexpected sender %+v", s)
+	}
+	if _, err = s.Send(SenderData{Host: "vm1", Key: "agent.ping", Value: "1"}); err == nil || !strings.HasPrefix(err.Error(), "failed to connect") {
+		t.Errorf("expected TLS handshake error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package zabbix
+
+import "time"
+
+type (
+	AlertType   int
+	AlertStatus int
+)
+
+const (
+	AlertMessage AlertType = 0
+	AlertCommand AlertType = 1
+)
+
+// Status of message alert. Command alerts use AlertNotSent for not executed,
+// AlertSent for executed and AlertFailed for failed.
+const (
+	AlertNotSent AlertStatus = 0
+	AlertSent    AlertStatus = 1
+	AlertFailed  AlertStatus = 2
+	AlertNew     AlertStatus = 3
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/alert/object
+// Alerts are created by server, there are only get methods.
+type Alert struct {
+	AlertId        string      `json:"alertid"`
+	ActionId       string      `json:"actionid"`
+	EventId        string      `json:"eventid"`
+	ProblemEventId string      `json:"p_eventid"` // problem event of recovery alert
+	UserId         string      `json:"userid"`
+	MediaTypeId    string      `json:"mediatypeid"`
+	AlertType      AlertType   `json:"alerttype"`
+	Clock          int64       `json:"clock"`
+	EscStep        int         `json:"esc_step"`
+	SendTo         string      `json:"sendto"`
+	Subject        string      `json:"subject"`
+	Message        string      `json:"message"`
+	Status         AlertStatus `json:"status"`
+	Retries        int         `json:"retries"`
+	Error          string      `json:"error"`
+
+	// Use "selectHosts", "selectMediatypes" and "selectUsers" to get them.
+	Hosts      Hosts      `json:"hosts,omitempty"`
+	MediaTypes MediaTypes `json:"mediatypes,omitempty"`
+	Users      Users      `json:"users,omitempty"`
+}
+
+type Alerts []Alert
+
+func (a *Alert) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "alert", IdField: "alertid"} }
+func (a *Alert) ObjectId() string       { return a.AlertId }
+func (a *Alert) SetObjectId(id string)  { a.AlertId = id }
+
+// Returns alert time.
+func (a *Alert) Time() time.Time {
+	return time.Unix(a.Clock, 0)
+}
+
+// Returns true if message was not sent or command was not executed because of error.
+func (a *Alert) Failed() bool {
+	return a.Status == AlertFailed
+}
+
+// Returns failed alerts.
+func (alerts Alerts) Failed() (res Alerts) {
+	for _, a := range alerts {
+		if a.Failed() {
+			res = append(res, a)
+		}
+	}
+	return
+}
+
+// Wrapper for alert.get: https://www.zabbix.com/documentation/current/manual/api/reference/alert/get
+func (api *API) AlertsGet(params Params) (res Alerts, err error) {
+	return GetObjects[Alert](api, params)
+}
@@ -26,6 +26,11 @@ type VersionInfo struct {
 	UseUsername bool // true for Zabbix 6.4+
 }
 
+// Returns true if version is major.minor or later.
+func (v *VersionInfo) AtLeast(major, minor int64) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
 type request struct {
 	Jsonrpc string      `json:"jsonrpc"`
 	Method  string      `json:"method"`
@@ -174,15 +179,26 @@ func (api *API) callBytes(method string, params interface{}) (b []byte, err erro
 		return
 	}
 	api.printf("Response (%d): %s", res.StatusCode, b)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body := b
+		if len(body) > maxRawSnippet {
+			body = body[:maxRawSnippet]
+		}
+		err = &HTTPError{StatusCode: res.StatusCode, Status: res.Status, Body: body}
+	}
 	return
 }
 
 // Calls specified API method. Uses api.Auth if not empty.
-// err is something network or marshaling related. Caller should inspect response.Error to get API error.
+// err is something network or marshaling related (*HTTPError or *DecodeError for bad responses).
+// Caller should inspect response.Error to get API error.
 func (api *API) Call(method string, params interface{}) (response Response, err error) {
 	b, err := api.callBytes(method, params)
 	if err == nil {
-		err = json.Unmarshal(b, &response)
+		if e := json.Unmarshal(b, &response); e != nil {
+			err = newDecodeError(method, b, e)
+		}
 	}
 	return
 }
@@ -196,6 +212,33 @@ func (api *API) CallWithError(method string, params interface{}) (response Respo
 	return
 }
 
+// Calls specified API method and unmarshals response result into v, accepting numbers encoded as strings.
+// err is set to response error if there is one, or to *DecodeError if response can't be decoded.
+func (api *API) callResult(method string, params interface{}, v interface{}) (err error) {
+	b, err := api.callBytes(method, params)
+	if err != nil {
+		return
+	}
+
+	var response struct {
+		Error  *Error          `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err = json.Unmarshal(b, &response); err != nil {
+		return newDecodeError(method, b, err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if len(response.Result) == 0 {
+		return newDecodeError(method, b, fmt.Errorf("no result"))
+	}
+	if err = unmarshalLenient(response.Result, v); err != nil {
+		return newDecodeError(method, response.Result, err)
+	}
+	return
+}
+
 // Calls "user.login" API method and fills api.Auth field.
 // This method modifies API structure and should not be called concurrently with other methods.
 func (api *API) Login(user, password string) (auth string, err error) {
@@ -218,25 +261,33 @@ func (api *API) Login(user, password string) (auth string, err error) {
 		"password": password,
 	}
 
-	response, err := api.CallWithError("user.login", params)
+	err = api.callResult("user.login", params, &auth)
 	if err != nil {
 		return
 	}
-	auth = response.Result.(string)
 	api.Auth = auth
 	return
 }
 
+// Calls "user.logout" API method and clears api.Auth field.
+// This method modifies API structure and should not be called concurrently with other methods.
+func (api *API) Logout() (err error) {
+	var res bool
+	err = api.callResult("user.logout", []string{}, &res)
+	if err == nil {
+		api.Auth = ""
+	}
+	return
+}
+
 // Calls "APIInfo.version" API method and caches version information.
 func (api *API) Version() (v string, err error) {
 	// APIInfo.version doesn't require authentication
-	response, err := api.CallWithError("APIInfo.version", Params{})
+	err = api.callResult("APIInfo.version", Params{}, &v)
 	if err != nil {
 		return
 	}
 
-	v = response.Result.(string)
-
 	// Parse version string (e.g., "7.4.0")
 	verArr := strings.Split(v, ".")
 	if len(verArr) < 2 {
@@ -251,14 +302,15 @@ func (api *API) Version() (v string, err error) {
 	}
 
 	// Cache version information
-	api.versionInfo = &VersionInfo{
-		Version:     v,
-		Major:       major,
-		Minor:       minor,
-		Patch:       patch,
-		UseBearer:   major > 7 || (major == 7 && minor >= 2), // Zabbix 7.2+ uses Bearer token
-		UseUsername: major > 6 || (major == 6 && minor >= 4), // Zabbix 6.4+ uses "username" instead of "user"
+	info := &VersionInfo{
+		Version: v,
+		Major:   major,
+		Minor:   minor,
+		Patch:   patch,
 	}
+	info.UseBearer = info.Supports(FeatureBearerAuth) // Zabbix 7.2+ uses Bearer token
+	info.UseUsername = info.Supports(FeatureUsername) // Zabbix 6.4+ uses "username" instead of "user"
+	api.versionInfo = info
 
 	return v, nil
 }
@@ -271,6 +323,16 @@ func (api *API) SetAuth(auth string) error {
 	return err
 }
 
+// Returns cached version information, calling Version() if needed.
+func (api *API) version() (*VersionInfo, error) {
+	if api.versionInfo == nil {
+		if _, err := api.Version(); err != nil {
+			return nil, err
+		}
+	}
+	return api.versionInfo, nil
+}
+
 // GetVersionInfo returns cached version information
 func (api *API) GetVersionInfo() *VersionInfo {
 	return api.versionInfo
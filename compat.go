@@ -0,0 +1,242 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Feature is an API feature present only in some Zabbix versions.
+type Feature int
+
+const (
+	_                         Feature = iota
+	FeatureHostDeleteIds              // host.delete takes array of Ids
+	FeatureItemDataType               // item "data_type" and "delta" fields
+	FeatureHostTags                   // host tags
+	FeatureApplications               // application.* methods and item applications, replaced by item tags
+	FeatureItemTags                   // item tags
+	FeatureSelectHostGroups           // host.get "selectHostGroups" parameter
+	FeatureSelectGroups               // host.get "selectGroups" parameter
+	FeatureUsername                   // user.login "username" parameter
+	FeatureHistoryPush                // history.push method
+	FeatureProxyId                    // host "proxyid" field, "proxy_hostid" before
+	FeatureProxyGroups                // proxy groups and host "monitored_by" field
+	FeatureBearerAuth                 // authentication with Authorization header
+	FeatureMacroTypes                 // user macro "type" field: secret and vault macros
+	FeatureMaintenanceTags            // maintenance problem tags
+	FeatureMaintenanceObjects         // maintenance "hosts" and "groups" objects, "hostids" and "groupids" before
+	FeatureRoles                      // role.* methods and user "roleid" field
+	FeatureUserType                   // user "type" field, replaced by roles
+	FeatureUserMedias                 // user "medias" parameter, "user_medias" before
+	FeatureUserUsername               // user "username" field, "alias" before
+	FeatureTemplateGroups             // template groups and user group "hostgroup_rights" and "templategroup_rights"
+	FeatureUserGroupRights            // user group "rights", replaced by "hostgroup_rights"
+	FeatureTokens                     // token.* methods
+	FeatureMediaTypeName              // media type "name" field, "description" was name before
+	FeatureMediaTypeTest              // mediatype.test method
+	FeatureConfigurationYAML          // YAML import and export format
+	FeatureTemplateDashboards         // import rule "templateDashboards", "templateScreens" before
+	FeatureImportCompare              // configuration.importcompare method
+	FeatureProxyOperatingMode         // proxy "name", "operating_mode" and "address" fields, "host", "status" and "interface" before
+	FeatureHttpFields                 // web scenario variables, headers and posts as objects, "name=value" strings before
+	FeatureDashboardPages             // dashboard pages, single list of "widgets" before
+	FeatureWidgetFieldIndex           // widget reference fields with index suffix, like "groupids.0"
+	FeatureMapShapes                  // map shapes
+	FeatureMapElements                // map element "elements", single "elementid" before
+	FeatureSLA                        // sla.* methods and services with status rules, IT services with "goodsla" before
+)
+
+type version struct {
+	major, minor int64
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// Capability table: feature is supported by versions in [since, until), zero means unbounded.
+var features = map[Feature]struct {
+	name         string
+	since, until version
+}{
+	FeatureHostDeleteIds:      {"host.delete with Ids", version{2, 4}, version{}},
+	FeatureItemDataType:       {"item data_type and delta", version{}, version{3, 4}},
+	FeatureHostTags:           {"host tags", version{4, 2}, version{}},
+	FeatureApplications:       {"applications", version{}, version{5, 4}},
+	FeatureItemTags:           {"item tags", version{5, 4}, version{}},
+	FeatureSelectHostGroups:   {"selectHostGroups", version{6, 2}, version{}},
+	FeatureSelectGroups:       {"selectGroups", version{}, version{7, 0}},
+	FeatureUsername:           {"user.login username", version{6, 4}, version{}},
+	FeatureHistoryPush:        {"history.push", version{7, 0}, version{}},
+	FeatureProxyId:            {"host proxyid", version{7, 0}, version{}},
+	FeatureProxyGroups:        {"proxy groups", version{7, 0}, version{}},
+	FeatureBearerAuth:         {"Bearer authentication", version{7, 2}, version{}},
+	FeatureMacroTypes:         {"secret and vault macros", version{5, 0}, version{}},
+	FeatureMaintenanceTags:    {"maintenance tags", version{4, 0}, version{}},
+	FeatureMaintenanceObjects: {"maintenance hosts and groups", version{6, 0}, version{}},
+	FeatureRoles:              {"user roles", version{5, 2}, version{}},
+	FeatureUserType:           {"user type", version{}, version{5, 2}},
+	FeatureUserMedias:         {"user medias", version{5, 2}, version{}},
+	FeatureUserUsername:       {"user username", version{5, 4}, version{}},
+	FeatureTemplateGroups:     {"template groups", version{6, 2}, version{}},
+	FeatureUserGroupRights:    {"user group rights", version{}, version{6, 2}},
+	FeatureTokens:             {"API tokens", version{5, 4}, version{}},
+	FeatureMediaTypeName:      {"media type name", version{4, 4}, version{}},
+	FeatureMediaTypeTest:      {"mediatype.test", version{7, 0}, version{}},
+	FeatureConfigurationYAML:  {"YAML configuration format", version{5, 0}, version{}},
+	FeatureTemplateDashboards: {"template dashboards", version{5, 2}, version{}},
+	FeatureImportCompare:      {"configuration.importcompare", version{6, 0}, version{}},
+	FeatureProxyOperatingMode: {"proxy operating mode", version{7, 0}, version{}},
+	FeatureHttpFields:         {"web scenario fields", version{4, 0}, version{}},
+	FeatureDashboardPages:     {"dashboard pages", version{5, 4}, version{}},
+	FeatureWidgetFieldIndex:   {"indexed widget fields", version{7, 0}, version{}},
+	FeatureMapShapes:          {"map shapes", version{3, 4}, version{}},
+	FeatureMapElements:        {"map element objects", version{4, 0}, version{}},
+	FeatureSLA:                {"services and SLA", version{6, 0}, version{}},
+}
+
+func (f Feature) String() string {
+	if info, ok := features[f]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Feature(%d)", int(f))
+}
+
+// Returns true if feature is supported by this version.
+func (v *VersionInfo) Supports(f Feature) bool {
+	info, ok := features[f]
+	if !ok {
+		return true
+	}
+	if info.since != (version{}) && !v.AtLeast(info.since.major, info.since.minor) {
+		return false
+	}
+	if info.until != (version{}) && v.AtLeast(info.until.major, info.until.minor) {
+		return false
+	}
+	return true
+}
+
+// ErrUnsupportedInVersion is matched by errors.Is for all *UnsupportedError errors.
+var ErrUnsupportedInVersion = errors.New("unsupported in this Zabbix version")
+
+// UnsupportedError is returned when requested feature is not supported by Zabbix server version.
+type UnsupportedError struct {
+	Feature Feature
+	Version string // server version
+}
+
+func (e *UnsupportedError) Error() string {
+	info := features[e.Feature]
+	var r string
+	switch {
+	case info.since != (version{}) && info.until != (version{}):
+		r = fmt.Sprintf("Zabbix %s to %s", info.since, info.until)
+	case info.since != (version{}):
+		r = fmt.Sprintf("Zabbix %s or later", info.since)
+	default:
+		r = fmt.Sprintf("Zabbix before %s", info.until)
+	}
+	return fmt.Sprintf("%s requires %s, server version is %s", e.Feature, r, e.Version)
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupportedInVersion
+}
+
+// Returns *UnsupportedError if server doesn't support feature. Zero feature is always supported.
+func (api *API) require(f Feature) error {
+	if f == 0 {
+		return nil
+	}
+	v, err := api.version()
+	if err != nil {
+		return err
+	}
+	if !v.Supports(f) {
+		return &UnsupportedError{Feature: f, Version: v.Version}
+	}
+	return nil
+}
+
+// Field rule renames or removes field or parameter when feature is not supported.
+type fieldRule struct {
+	prefix   string // object method prefix
+	field    string
+	feature  Feature
+	renameTo string // empty to remove field
+}
+
+// Rules for fields of created and updated objects.
+var objectFieldRules = []fieldRule{
+	{"item", "data_type", FeatureItemDataType, ""},
+	{"item", "delta", FeatureItemDataType, ""},
+	{"item", "applications", FeatureApplications, ""},
+	{"item", "tags", FeatureItemTags, ""},
+	{"host", "tags", FeatureHostTags, ""},
+	{"host", "proxyid", FeatureProxyId, "proxy_hostid"},
+	{"host", "proxy_groupid", FeatureProxyGroups, ""},
+	{"host", "monitored_by", FeatureProxyGroups, ""},
+	{"usermacro", "type", FeatureMacroTypes, ""},
+	{"maintenance", "tags", FeatureMaintenanceTags, ""},
+	{"maintenance", "tags_evaltype", FeatureMaintenanceTags, ""},
+	{"user", "username", FeatureUserUsername, "alias"},
+	{"user", "roleid", FeatureRoles, ""},
+	{"user", "type", FeatureUserType, ""},
+	{"user", "medias", FeatureUserMedias, "user_medias"},
+	{"usergroup", "hostgroup_rights", FeatureTemplateGroups, "rights"},
+	{"usergroup", "templategroup_rights", FeatureTemplateGroups, ""},
+	{"mediatype", "description", FeatureMediaTypeName, ""}, // removed before "name" takes its place
+	{"mediatype", "name", FeatureMediaTypeName, "description"},
+	{"configuration", "host_groups", FeatureTemplateGroups, "groups"},
+	{"configuration", "template_groups", FeatureTemplateGroups, ""},
+	{"configuration", "templateDashboards", FeatureTemplateDashboards, "templateScreens"},
+	{"proxy", "name", FeatureProxyOperatingMode, "host"},
+	{"proxy", "allowed_addresses", FeatureProxyOperatingMode, "proxy_address"},
+	{"proxy", "proxy_groupid", FeatureProxyGroups, ""},
+	{"proxy", "local_address", FeatureProxyGroups, ""},
+	{"proxy", "local_port", FeatureProxyGroups, ""},
+	{"httptest", "tags", FeatureItemTags, ""},
+	{"dashboard", "display_period", FeatureDashboardPages, ""},
+	{"dashboard", "auto_start", FeatureDashboardPages, ""},
+	{"map", "shapes", FeatureMapShapes, ""},
+}
+
+// Rules for parameters of get methods.
+var getParamRules = []fieldRule{
+	{"host", "selectGroups", FeatureSelectGroups, "selectHostGroups"},
+	{"host", "selectHostGroups", FeatureSelectHostGroups, "selectGroups"},
+	{"maintenance", "selectGroups", FeatureSelectGroups, "selectHostGroups"},
+	{"maintenance", "selectHostGroups", FeatureSelectHostGroups, "selectGroups"},
+	{"maintenance", "selectTags", FeatureMaintenanceTags, ""},
+	{"user", "selectRole", FeatureRoles, ""},
+	{"usergroup", "selectRights", FeatureUserGroupRights, "selectHostGroupRights"},
+	{"usergroup", "selectHostGroupRights", FeatureTemplateGroups, "selectRights"},
+	{"usergroup", "selectTemplateGroupRights", FeatureTemplateGroups, ""},
+	{"dashboard", "selectPages", FeatureDashboardPages, "selectWidgets"},
+	{"map", "selectShapes", FeatureMapShapes, ""},
+}
+
+func applyFieldRules(v *VersionInfo, rules []fieldRule, prefix string, m map[string]interface{}) {
+	for _, r := range rules {
+		if r.prefix != prefix || v.Supports(r.feature) {
+			continue
+		}
+		value, present := m[r.field]
+		if !present {
+			continue
+		}
+		delete(m, r.field)
+		if r.renameTo != "" {
+			if _, present := m[r.renameTo]; !present {
+				m[r.renameTo] = value
+			}
+		}
+	}
+}
+
+// objectPreparer is implemented by objects which need version-specific changes of create and update parameters
+// beyond renaming and removing fields.
+type objectPreparer interface {
+	prepare(v *VersionInfo, m map[string]interface{})
+}
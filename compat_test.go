@@ -0,0 +1,118 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestCompatItemCreate(t *testing.T) {
+	for version, expected := range map[string]string{
+		"3.2.0": `[{"applications":["5"],"data_type":0,"delay":"","delta":0,"description":"","hostid":"1","key_":"k","name":"n","type":2,"value_type":0}]`,
+		"6.0.0": `[{"delay":"","description":"","hostid":"1","key_":"k","name":"n","tags":[{"tag":"Application","value":"CPU"}],"type":2,"value_type":0}]`,
+	} {
+		var created json.RawMessage
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"item.create": func(params json.RawMessage) interface{} {
+				created = params
+				return map[string]interface{}{"itemids": []string{"10"}}
+			},
+		})
+
+		app := "5"
+		if version == "6.0.0" {
+			app = "CPU"
+		}
+		items := Items{{HostId: "1", Key: "k", Name: "n", Type: ZabbixTrapper, Lastvalue: "x", ApplicationIds: []string{app}}}
+		if err := api.ItemsCreate(items); err != nil {
+			t.Fatal(err)
+		}
+		if string(created) != expected {
+			t.Errorf("%s: unexpected params:\n%s\n%s", version, created, expected)
+		}
+	}
+}
+
+func TestCompatHost(t *testing.T) {
+	var created, get Params
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"host.create": func(params json.RawMessage) interface{} {
+			var p []Params
+			json.Unmarshal(params, &p)
+			created = p[0]
+			return map[string]interface{}{"hostids": []string{"10"}}
+		},
+		"host.get": func(params json.RawMessage) interface{} {
+			get = decodeParams(t, params)
+			return []interface{}{}
+		},
+	})
+
+	if err := api.HostsCreate(Hosts{{Host: "h", ProxyId: "3", MonitoredBy: MonitoredByProxy}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"proxyid", "monitored_by", "available", "error"} {
+		if _, present := created[field]; present {
+			t.Errorf("Unexpected %q in params: %#v", field, created)
+		}
+	}
+	if created["proxy_hostid"] != "3" {
+		t.Errorf("Expected proxy_hostid in params: %#v", created)
+	}
+
+	if _, err := api.HostsGet(HostQuery{SelectHostGroups: OutputExtend}.Params()); err != nil {
+		t.Fatal(err)
+	}
+	if _, present := get["selectHostGroups"]; present || get["selectGroups"] != "extend" {
+		t.Errorf("Unexpected host.get params: %#v", get)
+	}
+}
+
+func TestCompatUnsupported(t *testing.T) {
+	api := newFakeAPI(t, "5.4.0", nil)
+
+	_, err := api.ApplicationsGet(Params{})
+	if !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Fatalf("Expected ErrUnsupportedInVersion, got %v", err)
+	}
+	if err.Error() != "applications requires Zabbix before 5.4, server version is 5.4.0" {
+		t.Errorf("Unexpected message: %s", err)
+	}
+	if err = api.ApplicationsCreate(Applications{{Name: "a"}}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("Expected ErrUnsupportedInVersion, got %v", err)
+	}
+}
+
+func TestCompatItemsGetByApplication(t *testing.T) {
+	for version, expected := range map[string]string{
+		"5.0.0": `{"applicationids":["5"],"hostids":"1","output":"extend"}`,
+		"6.0.0": `{"hostids":"1","output":"extend","tags":[{"operator":1,"tag":"Application","value":"CPU"}]}`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"application.get": func(params json.RawMessage) interface{} {
+				return []map[string]string{{"applicationid": "5", "hostid": "1", "name": "CPU"}}
+			},
+			"item.get": func(params json.RawMessage) interface{} {
+				if string(params) != expected {
+					t.Errorf("%s: expected\n%s\ngot\n%s", version, expected, params)
+				}
+				return []map[string]string{{"itemid": "10"}}
+			},
+		})
+		items, err := api.ItemsGetByApplicationName("1", "CPU")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].ItemId != "10" {
+			t.Errorf("%s: unexpected items %#v", version, items)
+		}
+
+		expected = `{"applicationids":"5","output":"extend"}`
+		_, err = api.ItemsGetByApplicationId("5")
+		if unsupported := errors.Is(err, ErrUnsupportedInVersion); unsupported != (version == "6.0.0") {
+			t.Errorf("%s: unexpected error %v", version, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type ConfigurationFormat string
+
+const (
+	FormatYAML ConfigurationFormat = "yaml" // Zabbix 5.0+
+	FormatXML  ConfigurationFormat = "xml"
+	FormatJSON ConfigurationFormat = "json"
+)
+
+// Objects to export by Ids.
+type ConfigurationObjects struct {
+	HostGroups     []string `json:"host_groups,omitempty"`     // "groups" before Zabbix 6.2
+	TemplateGroups []string `json:"template_groups,omitempty"` // Zabbix 6.2+
+	Hosts          []string `json:"hosts,omitempty"`
+	Templates      []string `json:"templates,omitempty"`
+	Maps           []string `json:"maps,omitempty"`
+	MediaTypes     []string `json:"mediaTypes,omitempty"`
+	Images         []string `json:"images,omitempty"`
+}
+
+// Import rule for object type. Only flags supported by object type should be set:
+// https://www.zabbix.com/documentation/current/manual/api/reference/configuration/import#parameters
+type ImportRule struct {
+	CreateMissing  bool `json:"createMissing,omitempty"`
+	UpdateExisting bool `json:"updateExisting,omitempty"`
+	DeleteMissing  bool `json:"deleteMissing,omitempty"`
+}
+
+// Import rules by object type, nil rule leaves objects of this type untouched.
+type ImportRules struct {
+	DiscoveryRules     *ImportRule `json:"discoveryRules,omitempty"`
+	Graphs             *ImportRule `json:"graphs,omitempty"`
+	HostGroups         *ImportRule `json:"host_groups,omitempty"`     // "groups" before Zabbix 6.2
+	TemplateGroups     *ImportRule `json:"template_groups,omitempty"` // Zabbix 6.2+
+	Hosts              *ImportRule `json:"hosts,omitempty"`
+	HttpTests          *ImportRule `json:"httptests,omitempty"`
+	Images             *ImportRule `json:"images,omitempty"`
+	Items              *ImportRule `json:"items,omitempty"`
+	Maps               *ImportRule `json:"maps,omitempty"`
+	MediaTypes         *ImportRule `json:"mediaTypes,omitempty"`
+	TemplateLinkage    *ImportRule `json:"templateLinkage,omitempty"`
+	Templates          *ImportRule `json:"templates,omitempty"`
+	TemplateDashboards *ImportRule `json:"templateDashboards,omitempty"` // "templateScreens" before Zabbix 5.2
+	Triggers           *ImportRule `json:"triggers,omitempty"`
+	ValueMaps          *ImportRule `json:"valueMaps,omitempty"`
+}
+
+// Returns options or rules encoded for server version.
+func (api *API) configurationParams(format ConfigurationFormat, v interface{}) (res map[string]interface{}, err error) {
+	info, err := api.version()
+	if err != nil {
+		return
+	}
+	if format == FormatYAML && !info.Supports(FeatureConfigurationYAML) {
+		return nil, &UnsupportedError{Feature: FeatureConfigurationYAML, Version: info.Version}
+	}
+	if err = jsonParams(v, &res); err != nil {
+		return
+	}
+	applyFieldRules(info, objectFieldRules, "configuration", res)
+	return
+}
+
+// Wrapper for configuration.export: https://www.zabbix.com/documentation/current/manual/api/reference/configuration/export
+// Returns exported objects serialized in format.
+func (api *API) ConfigurationExport(format ConfigurationFormat, objects ConfigurationObjects) (res string, err error) {
+	options, err := api.configurationParams(format, &objects)
+	if err != nil {
+		return
+	}
+	err = api.callResult("configuration.export", Params{"format": format, "options": options}, &res)
+	return
+}
+
+// Wrapper for configuration.import: https://www.zabbix.com/documentation/current/manual/api/reference/configuration/import
+// Imports source serialized in format.
+func (api *API) ConfigurationImport(format ConfigurationFormat, source string, rules ImportRules) (err error) {
+	r, err := api.configurationParams(format, &rules)
+	if err != nil {
+		return
+	}
+	var res bool
+	err = api.callResult("configuration.import", Params{"format": format, "source": source, "rules": r}, &res)
+	return
+}
+
+// Wrapper for configuration.importcompare (Zabbix 6.0+):
+// https://www.zabbix.com/documentation/current/manual/api/reference/configuration/importcompare
+// Returns changes which ConfigurationImport with the same arguments would make.
+func (api *API) ConfigurationImportCompare(format ConfigurationFormat, source string, rules ImportRules) (res ConfigurationDiff, err error) {
+	if err = api.require(FeatureImportCompare); err != nil {
+		return
+	}
+	r, err := api.configurationParams(format, &rules)
+	if err != nil {
+		return
+	}
+	err = api.callResult("configuration.importcompare", Params{"format": format, "source": source, "rules": r}, &res)
+	return
+}
+
+// Exported object, like template or item, as returned by configuration.importcompare.
+type ConfigurationObject map[string]interface{}
+
+// Returns name of object: first of "name", "host", "key", "expression" and "uuid" fields present.
+func (o ConfigurationObject) Name() string {
+	for _, k := range []string{"name", "host", "key", "expression", "uuid"} {
+		if s, ok := o[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Changes of objects of one type.
+type ConfigurationChanges struct {
+	Added   []ConfigurationObject `json:"added,omitempty"`
+	Removed []ConfigurationObject `json:"removed,omitempty"`
+	Updated []ConfigurationUpdate `json:"updated,omitempty"`
+}
+
+// Updated object with changes of its sub-objects, like items of template.
+type ConfigurationUpdate struct {
+	Before  ConfigurationObject
+	After   ConfigurationObject
+	Changes ConfigurationDiff
+}
+
+// Decodes "before", "after" and changes of sub-objects by type.
+func (u *ConfigurationUpdate) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	*u = ConfigurationUpdate{}
+	for k, raw := range fields {
+		var err error
+		switch k {
+		case "before":
+			err = unmarshalLenient(raw, &u.Before)
+		case "after":
+			err = unmarshalLenient(raw, &u.After)
+		default:
+			var c ConfigurationChanges
+			if err = unmarshalLenient(raw, &c); err == nil {
+				if u.Changes == nil {
+					u.Changes = make(ConfigurationDiff)
+				}
+				u.Changes[k] = &c
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// Changes by object type, like "templates" or "items", as returned by configuration.importcompare.
+type ConfigurationDiff map[string]*ConfigurationChanges
+
+// Returns true if there are no changes.
+func (d ConfigurationDiff) Empty() bool {
+	for _, c := range d {
+		if c != nil && len(c.Added)+len(c.Removed)+len(c.Updated) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Renders changes as Markdown nested list, suitable for pull request comment.
+func (d ConfigurationDiff) Markdown() string {
+	var buf bytes.Buffer
+	if d.Empty() {
+		buf.WriteString("No changes.\n")
+		return buf.String()
+	}
+	d.writeMarkdown(&buf, "")
+	return buf.String()
+}
+
+func (d ConfigurationDiff) writeMarkdown(buf *bytes.Buffer, indent string) {
+	types := make([]string, 0, len(d))
+	for t := range d {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		c := d[t]
+		if c == nil || len(c.Added)+len(c.Removed)+len(c.Updated) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "%s- **%s**\n", indent, t)
+		for _, o := range c.Added {
+			fmt.Fprintf(buf, "%s  - added %s\n", indent, markdownCode(o.Name()))
+		}
+		for _, o := range c.Removed {
+			fmt.Fprintf(buf, "%s  - removed %s\n", indent, markdownCode(o.Name()))
+		}
+		for _, u := range c.Updated {
+			name := u.After.Name()
+			if name == "" {
+				name = u.Before.Name()
+			}
+			fmt.Fprintf(buf, "%s  - updated %s\n", indent, markdownCode(name))
+			for _, k := range u.changedFields() {
+				fmt.Fprintf(buf, "%s    - %s: %s → %s\n", indent, markdownCode(k),
+					markdownValue(u.Before[k]), markdownValue(u.After[k]))
+			}
+			u.Changes.writeMarkdown(buf, indent+"    ")
+		}
+	}
+}
+
+// Returns sorted names of fields with different values before and after update.
+func (u *ConfigurationUpdate) changedFields() (res []string) {
+	seen := make(map[string]bool)
+	for _, o := range []ConfigurationObject{u.Before, u.After} {
+		for k := range o {
+			if !seen[k] && !reflect.DeepEqual(u.Before[k], u.After[k]) {
+				res = append(res, k)
+			}
+			seen[k] = true
+		}
+	}
+	sort.Strings(res)
+	return
+}
+
+const maxMarkdownValue = 80
+
+func markdownValue(v interface{}) string {
+	if v == nil {
+		return "*none*"
+	}
+	s, ok := v.(string)
+	if !ok {
+		b, _ := json.Marshal(v)
+		s = string(b)
+	}
+	if r := []rune(s); len(r) > maxMarkdownValue {
+		s = string(r[:maxMarkdownValue]) + "…"
+	}
+	return markdownCode(s)
+}
+
+func markdownCode(s string) string {
+	s = strings.NewReplacer("`", "'", "\r", "", "\n", "\\n").Replace(s)
+	return "`" + s + "`"
+}
@@ -0,0 +1,126 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestConfigurationExportVersions(t *testing.T) {
+	for version, key := range map[string]string{"6.0.0": "groups", "6.4.0": "host_groups"} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"configuration.export": func(raw json.RawMessage) interface{} {
+				p := decodeParams(t, raw)
+				options := p["options"].(map[string]interface{})
+				if p["format"] != "yaml" || options[key] == nil || options["templates"] == nil {
+					t.Errorf("%s: unexpected params %s", version, raw)
+				}
+				return "zabbix_export: {}\n"
+			},
+		})
+		res, err := api.ConfigurationExport(FormatYAML, ConfigurationObjects{HostGroups: []string{"2"}, Templates: []string{"10001"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != "zabbix_export: {}\n" {
+			t.Errorf("unexpected result %q", res)
+		}
+	}
+
+	api := newFakeAPI(t, "4.4.0", nil)
+	if _, err := api.ConfigurationExport(FormatYAML, ConfigurationObjects{}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+}
+
+func TestConfigurationImport(t *testing.T) {
+	api := newFakeAPI(t, "5.0.0", map[string]fakeHandler{
+		"configuration.import": func(raw json.RawMessage) interface{} {
+			var p struct {
+				Format string                     `json:"format"`
+				Source string                     `json:"source"`
+				Rules  map[string]json.RawMessage `json:"rules"`
+			}
+			if err := json.Unmarshal(raw, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			if p.Format != "xml" || p.Source != "<zabbix_export/>" || len(p.Rules) != 3 ||
+				string(p.Rules["groups"]) != `{"createMissing":true}` ||
+				string(p.Rules["templates"]) != `{"createMissing":true,"updateExisting":true}` ||
+				string(p.Rules["templateScreens"]) != `{"deleteMissing":true}` {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return true
+		},
+	})
+	err := api.ConfigurationImport(FormatXML, "<zabbix_export/>", ImportRules{
+		HostGroups:         &ImportRule{CreateMissing: true},
+		Templates:          &ImportRule{CreateMissing: true, UpdateExisting: true},
+		TemplateDashboards: &ImportRule{DeleteMissing: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigurationImportCompare(t *testing.T) {
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"configuration.importcompare": func(raw json.RawMessage) interface{} {
+			var res interface{}
+			json.Unmarshal([]byte(`{
+				"templates": {
+					"updated": [{
+						"before": {"uuid": "a1", "template": "Linux", "name": "Linux", "description": "old"},
+						"after": {"uuid": "a1", "template": "Linux", "name": "Linux", "description": "new", "vendor": {"name": "Zabbix"}},
+						"items": {
+							"added": [{"uuid": "b1", "name": "CPU load", "key": "system.cpu.load", "delay": 60}],
+							"removed": [{"uuid": "b2", "key": "agent.ping"}]
+						}
+					}],
+					"added": [{"uuid": "c1", "template": "Nginx", "name": "Nginx"}]
+				},
+				"template_groups": {"removed": [{"uuid": "d1", "name": "Old"}]}
+			}`), &res)
+			return res
+		},
+	})
+
+	diff, err := api.ConfigurationImportCompare(FormatJSON, "{}", ImportRules{Templates: &ImportRule{CreateMissing: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Empty() {
+		t.Fatal("expected changes")
+	}
+	if n := diff["templates"].Updated[0].Changes["items"].Added[0]["delay"]; n != float64(60) {
+		t.Errorf("unexpected delay %#v", n)
+	}
+
+	expected := "- **template_groups**\n" +
+		"  - removed `Old`\n" +
+		"- **templates**\n" +
+		"  - added `Nginx`\n" +
+		"  - updated `Linux`\n" +
+		"    - `description`: `old` → `new`\n" +
+		"    - `vendor`: *none* → `{\"name\":\"Zabbix\"}`\n" +
+		"    - **items**\n" +
+		"      - added `CPU load`\n" +
+		"      - removed `agent.ping`\n"
+	if md := diff.Markdown(); md != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, md)
+	}
+
+	api = newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"configuration.importcompare": func(raw json.RawMessage) interface{} { return []interface{}{} },
+	})
+	diff, err = api.ConfigurationImportCompare(FormatJSON, "{}", ImportRules{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() || diff.Markdown() != "No changes.\n" {
+		t.Errorf("unexpected diff %v", diff)
+	}
+}
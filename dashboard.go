@@ -0,0 +1,233 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type (
+	WidgetFieldType int
+	WidgetViewMode  int
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/object#dashboard-widget-field
+const (
+	WidgetFieldInt            WidgetFieldType = 0
+	WidgetFieldString         WidgetFieldType = 1
+	WidgetFieldHostGroup      WidgetFieldType = 2
+	WidgetFieldHost           WidgetFieldType = 3
+	WidgetFieldItem           WidgetFieldType = 4
+	WidgetFieldItemPrototype  WidgetFieldType = 5
+	WidgetFieldGraph          WidgetFieldType = 6
+	WidgetFieldGraphPrototype WidgetFieldType = 7
+	WidgetFieldMap            WidgetFieldType = 8
+	WidgetFieldService        WidgetFieldType = 9  // Zabbix 6.0+
+	WidgetFieldSLA            WidgetFieldType = 10 // Zabbix 6.0+
+	WidgetFieldUser           WidgetFieldType = 11 // Zabbix 6.0+
+	WidgetFieldAction         WidgetFieldType = 12 // Zabbix 6.0+
+	WidgetFieldMediaType      WidgetFieldType = 13 // Zabbix 6.0+
+)
+
+const (
+	WidgetViewDefault      WidgetViewMode = 0
+	WidgetViewHiddenHeader WidgetViewMode = 1
+)
+
+// Widget field. Fields with multiple values, like "groupids", are repeated with the same name;
+// references to objects are sent to Zabbix 7.0+ with index suffix, like "groupids.0" and "groupids.1".
+type WidgetField struct {
+	Type  WidgetFieldType `json:"type"`
+	Name  string          `json:"name"`
+	Value string          `json:"value"` // number, string or Id of object
+}
+
+type WidgetFields []WidgetField
+
+// Returns values of fields with name, or with name and index suffix.
+func (f WidgetFields) Values(name string) (res []string) {
+	for _, field := range f {
+		if base, index, ok := strings.Cut(field.Name, "."); field.Name == name || ok && base == name && isIndex(index) {
+			res = append(res, field.Value)
+		}
+	}
+	return
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/object#dashboard-widget
+// Grid of dashboard is 72 columns wide in Zabbix 7.0+, 24 columns before.
+type Widget struct {
+	WidgetId string         `json:"widgetid,omitempty" zabbix:"readonly"`
+	Type     string         `json:"type"` // e.g. "graph", "problems" or "item"
+	Name     string         `json:"name,omitempty"`
+	X        int            `json:"x"`
+	Y        int            `json:"y"`
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	ViewMode WidgetViewMode `json:"view_mode,omitempty"`
+	Fields   WidgetFields   `json:"fields,omitempty"`
+}
+
+type Widgets []Widget
+
+// Page of dashboard (Zabbix 5.4+). Dashboards of earlier versions have single page.
+type DashboardPage struct {
+	DashboardPageId string  `json:"dashboard_pageid,omitempty" zabbix:"readonly"`
+	Name            string  `json:"name,omitempty"`
+	DisplayPeriod   int     `json:"display_period,omitempty"` // seconds, 0 to use dashboard period
+	Widgets         Widgets `json:"widgets,omitempty"`
+}
+
+type DashboardPages []DashboardPage
+
+// Permission of user to shared dashboard.
+type DashboardUser struct {
+	UserId     string     `json:"userid"`
+	Permission Permission `json:"permission"` // PermissionRead or PermissionReadWrite
+}
+
+// Permission of user group to shared dashboard.
+type DashboardUserGroup struct {
+	UserGroupId string     `json:"usrgrpid"`
+	Permission  Permission `json:"permission"` // PermissionRead or PermissionReadWrite
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/object
+type Dashboard struct {
+	DashboardId   string `json:"dashboardid,omitempty"`
+	Name          string `json:"name"`
+	UserId        string `json:"userid,omitempty"`         // owner, current user by default
+	Private       *int   `json:"private,omitempty"`        // 0 - public, 1 - shared only with Users and UserGroups, nil for default (1)
+	DisplayPeriod int    `json:"display_period,omitempty"` // seconds, Zabbix 5.4+
+	AutoStart     int    `json:"auto_start,omitempty"`     // 1 to start slideshow, Zabbix 5.4+
+
+	// Use "selectPages", "selectUsers" and "selectUserGroups" to get them.
+	// Zabbix before 5.4 supports single page only.
+	Pages      DashboardPages       `json:"pages,omitempty"`
+	Users      []DashboardUser      `json:"users,omitempty"`
+	UserGroups []DashboardUserGroup `json:"userGroups,omitempty"`
+}
+
+type Dashboards []Dashboard
+
+// Decodes dashboard across Zabbix versions: fills Pages from "widgets" before Zabbix 5.4.
+func (d *Dashboard) UnmarshalJSON(b []byte) error {
+	type dashboard Dashboard
+	var v struct {
+		dashboard
+		Widgets Widgets `json:"widgets"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*d = Dashboard(v.dashboard)
+	if v.Widgets != nil && d.Pages == nil {
+		d.Pages = DashboardPages{{Widgets: v.Widgets}}
+	}
+	return nil
+}
+
+func (d *Dashboard) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "dashboard", IdField: "dashboardid"}
+}
+func (d *Dashboard) ObjectId() string      { return d.DashboardId }
+func (d *Dashboard) SetObjectId(id string) { d.DashboardId = id }
+
+// Sends widgets of the first page as "widgets" before Zabbix 5.4,
+// and adds index suffix to names of list fields for Zabbix 7.0+.
+func (d *Dashboard) prepare(v *VersionInfo, m map[string]interface{}) {
+	pages, _ := m["pages"].([]interface{})
+	if !v.Supports(FeatureDashboardPages) {
+		if _, present := m["pages"]; present {
+			delete(m, "pages")
+			if len(pages) > 0 {
+				if page, ok := pages[0].(map[string]interface{}); ok && page["widgets"] != nil {
+					m["widgets"] = page["widgets"]
+				}
+			}
+		}
+		return
+	}
+	if !v.Supports(FeatureWidgetFieldIndex) {
+		return
+	}
+
+	for _, p := range pages {
+		page, _ := p.(map[string]interface{})
+		widgets, _ := page["widgets"].([]interface{})
+		for _, w := range widgets {
+			widget, _ := w.(map[string]interface{})
+			fields, _ := widget["fields"].([]interface{})
+			indexWidgetFields(fields)
+		}
+	}
+}
+
+// Fields with list of values even if there is single value.
+var widgetListFields = map[string]bool{"severities": true}
+
+// Adds index suffix to names of object reference fields and fields with multiple values without one.
+func indexWidgetFields(fields []interface{}) {
+	count := make(map[string]int)
+	for _, f := range fields {
+		field, _ := f.(map[string]interface{})
+		name, _ := field["name"].(string)
+		count[name]++
+	}
+
+	next := make(map[string]int)
+	for _, f := range fields {
+		field, _ := f.(map[string]interface{})
+		name, _ := field["name"].(string)
+		t, _ := field["type"].(json.Number)
+		ft, _ := t.Int64()
+		if strings.Contains(name, ".") || WidgetFieldType(ft) < WidgetFieldHostGroup && count[name] < 2 && !widgetListFields[name] {
+			continue
+		}
+		field["name"] = name + "." + strconv.Itoa(next[name])
+		next[name]++
+	}
+}
+
+// Wrapper for dashboard.get: https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/get
+func (api *API) DashboardsGet(params Params) (res Dashboards, err error) {
+	return GetObjects[Dashboard](api, params)
+}
+
+// Gets dashboard with pages and sharing settings by Id only if there is exactly 1 matching dashboard.
+func (api *API) DashboardGetById(id string) (res *Dashboard, err error) {
+	return GetObject[Dashboard](api, Params{
+		"dashboardids":     id,
+		"selectPages":      "extend",
+		"selectUsers":      "extend",
+		"selectUserGroups": "extend",
+	})
+}
+
+// Wrapper for dashboard.create: https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/create
+func (api *API) DashboardsCreate(dashboards Dashboards) (err error) {
+	return CreateObjects[Dashboard](api, dashboards)
+}
+
+// Wrapper for dashboard.update: https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/update
+// Non-empty Pages, Users and UserGroups replace existing ones.
+func (api *API) DashboardsUpdate(dashboards Dashboards, fields ...string) (err error) {
+	return UpdateObjects[Dashboard](api, dashboards, fields...)
+}
+
+// Wrapper for dashboard.delete: https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/delete
+// Cleans DashboardId in all dashboards elements if call succeed.
+func (api *API) DashboardsDelete(dashboards Dashboards) (err error) {
+	return DeleteObjects[Dashboard](api, dashboards)
+}
+
+// Wrapper for dashboard.delete: https://www.zabbix.com/documentation/current/manual/api/reference/dashboard/delete
+func (api *API) DashboardsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Dashboard](api, ids)
+}
@@ -0,0 +1,87 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestDashboardCreateVersions(t *testing.T) {
+	dashboard := Dashboard{
+		Name: "Team", DisplayPeriod: 60,
+		Pages: DashboardPages{{Widgets: Widgets{
+			NewWidget(&ProblemsWidget{GroupIds: []string{"2", "4"}, Severities: []int{4}}, 0, 0, 12, 5),
+			NewWidget(&GraphWidget{GraphId: "50", ShowLegend: true}, 12, 0, 12, 5),
+		}}},
+		UserGroups: []DashboardUserGroup{{UserGroupId: "7", Permission: PermissionReadWrite}},
+	}
+	for version, expected := range map[string]string{
+		"5.0.0": `[{"name":"Team","userGroups":[{"permission":3,"usrgrpid":"7"}],"widgets":[` +
+			`{"fields":[{"name":"groupids","type":2,"value":"2"},{"name":"groupids","type":2,"value":"4"},{"name":"severities","type":0,"value":"4"}],"height":5,"type":"problems","width":12,"x":0,"y":0},` +
+			`{"fields":[{"name":"source_type","type":0,"value":"0"},{"name":"graphid","type":6,"value":"50"},{"name":"show_legend","type":0,"value":"1"}],"height":5,"type":"graph","width":12,"x":12,"y":0}]}]`,
+		"7.0.0": `[{"display_period":60,"name":"Team","pages":[{"widgets":[` +
+			`{"fields":[{"name":"groupids.0","type":2,"value":"2"},{"name":"groupids.1","type":2,"value":"4"},{"name":"severities.0","type":0,"value":"4"}],"height":5,"type":"problems","width":12,"x":0,"y":0},` +
+			`{"fields":[{"name":"source_type","type":0,"value":"0"},{"name":"graphid.0","type":6,"value":"50"},{"name":"show_legend","type":0,"value":"1"}],"height":5,"type":"graph","width":12,"x":12,"y":0}]}],` +
+			`"userGroups":[{"permission":3,"usrgrpid":"7"}]}]`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"dashboard.create": func(raw json.RawMessage) interface{} {
+				if string(raw) != expected {
+					t.Errorf("%s: expected\n%s\ngot\n%s", version, expected, raw)
+				}
+				return map[string]interface{}{"dashboardids": []string{"3"}}
+			},
+		})
+		dashboards := Dashboards{dashboard}
+		if err := api.DashboardsCreate(dashboards); err != nil {
+			t.Fatal(err)
+		}
+		if dashboards[0].DashboardId != "3" {
+			t.Errorf("unexpected Id %q", dashboards[0].DashboardId)
+		}
+		if dashboard.Pages[0].Widgets[0].Fields[0].Name != "groupids" {
+			t.Errorf("original dashboard changed: %+v", dashboard)
+		}
+	}
+}
+
+func TestDashboardDecode(t *testing.T) {
+	api := newFakeAPI(t, "5.0.0", map[string]fakeHandler{
+		"dashboard.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["selectWidgets"] != "extend" || p["selectPages"] != nil {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return json.RawMessage(`[{"dashboardid":"3","name":"Team","userid":"1","private":"1",
+				"widgets":[{"widgetid":"9","type":"item","x":"0","y":"0","width":"6","height":"3","view_mode":"1",
+					"fields":[{"type":"4","name":"itemid","value":"100"},{"type":"0","name":"decimal_places","value":"2"}]}],
+				"users":[{"userid":"5","permission":"2"}],"userGroups":[]}]`)
+		},
+	})
+	dashboard, err := api.DashboardGetById("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.Private == nil || *dashboard.Private != 1 || len(dashboard.Pages) != 1 || len(dashboard.Pages[0].Widgets) != 1 ||
+		!reflect.DeepEqual(dashboard.Users, []DashboardUser{{UserId: "5", Permission: PermissionRead}}) {
+		t.Fatalf("unexpected dashboard %+v", dashboard)
+	}
+	w := dashboard.Pages[0].Widgets[0]
+	if w.WidgetId != "9" || w.ViewMode != WidgetViewHiddenHeader || w.Width != 6 ||
+		!reflect.DeepEqual(w.Fields.Values("itemid"), []string{"100"}) {
+		t.Errorf("unexpected widget %+v", w)
+	}
+}
+
+func TestWidgetFieldsValues(t *testing.T) {
+	fields := (&TopHostsWidget{HostIds: []string{"10"}, Columns: []TopHostsColumn{{Name: "CPU", Item: "CPU utilization"}}}).WidgetFields()
+	fields = append(fields, WidgetField{Type: WidgetFieldHost, Name: "hostids.1", Value: "11"})
+	if v := fields.Values("hostids"); !reflect.DeepEqual(v, []string{"10", "11"}) {
+		t.Errorf("unexpected hostids %v", v)
+	}
+	if v := fields.Values("columns.0.item"); !reflect.DeepEqual(v, []string{"CPU utilization"}) {
+		t.Errorf("unexpected column item %v", v)
+	}
+}
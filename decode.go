@@ -0,0 +1,198 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DecodeError is returned when response can't be decoded, for example when
+// a proxy returns HTML page or server returns result of unexpected shape.
+type DecodeError struct {
+	Method string
+	Raw    []byte // raw response or result, possibly truncated
+	Err    error
+}
+
+const maxRawSnippet = 256
+
+func newDecodeError(method string, raw []byte, err error) *DecodeError {
+	if len(raw) > maxRawSnippet {
+		raw = append(raw[:maxRawSnippet:maxRawSnippet], "..."...)
+	}
+	return &DecodeError{Method: method, Raw: raw, Err: err}
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: can't decode response: %s: %q", e.Method, e.Err, e.Raw)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when API responds with non-2xx HTTP status code.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte // response body, possibly truncated
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %s: %q", e.Status, e.Body)
+}
+
+// Unmarshals JSON data into v like json.Unmarshal, but accepts Zabbix encoding:
+// numbers and booleans encoded as strings and empty arrays in place of empty objects.
+// Types implementing json.Unmarshaler get their data as is.
+func unmarshalLenient(data []byte, v interface{}) error {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return fmt.Errorf("unmarshalLenient: expected pointer, got %T", v)
+	}
+	if t.Implements(unmarshalerType) {
+		return json.Unmarshal(data, v)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(normalize(value, t.Elem()))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Converts decoded JSON value to the form expected by encoding/json for type t.
+func normalize(value interface{}, t reflect.Type) interface{} {
+	if value == nil || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return value
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return normalize(value, t.Elem())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if s, ok := value.(string); ok {
+			if s == "" {
+				return json.Number("0")
+			}
+			return json.Number(s)
+		}
+
+	case reflect.Bool:
+		switch v := value.(type) {
+		case string:
+			return v != "" && v != "0" && v != "false"
+		case json.Number:
+			return v.String() != "0"
+		}
+
+	case reflect.String:
+		if n, ok := value.(json.Number); ok {
+			return n.String()
+		}
+
+	case reflect.Slice, reflect.Array:
+		if a, ok := value.([]interface{}); ok {
+			res := make([]interface{}, len(a))
+			for i, e := range a {
+				res[i] = normalize(e, t.Elem())
+			}
+			return res
+		}
+
+	case reflect.Map:
+		switch v := value.(type) {
+		case []interface{}:
+			if len(v) == 0 {
+				return nil
+			}
+		case map[string]interface{}:
+			res := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				res[k] = normalize(e, t.Elem())
+			}
+			return res
+		}
+
+	case reflect.Struct:
+		switch v := value.(type) {
+		case []interface{}:
+			if len(v) == 0 {
+				return nil
+			}
+		case map[string]interface{}:
+			fields := structFields(t)
+			res := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				if ft, ok := fields[k]; ok {
+					e = normalize(e, ft)
+				} else if ft, ok := fields[strings.ToLower(k)]; ok {
+					e = normalize(e, ft)
+				}
+				res[k] = e
+			}
+			return res
+		}
+	}
+	return value
+}
+
+var structFieldsCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+// Returns types of struct fields by JSON name (and lowercased JSON name), including fields of embedded structs.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	// fields of embedded structs are added after own fields, so own fields take precedence
+	fields := make(map[string]reflect.Type)
+	var add func(t reflect.Type)
+	add = func(t reflect.Type) {
+		var embedded []reflect.Type
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+				embedded = append(embedded, sf.Type)
+				continue
+			}
+			if sf.PkgPath != "" || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			if _, present := fields[name]; !present {
+				fields[name] = sf.Type
+			}
+			if lower := strings.ToLower(name); lower != name {
+				if _, present := fields[lower]; !present {
+					fields[lower] = sf.Type
+				}
+			}
+		}
+		for _, e := range embedded {
+			add(e)
+		}
+	}
+	add(t)
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
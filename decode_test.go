@@ -0,0 +1,62 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Bad gateway</html>"))
+	}))
+	defer srv.Close()
+
+	_, err := NewAPI(srv.URL).HostsGet(Params{})
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Expected *DecodeError, got %#v", err)
+	}
+	if de.Method != "APIInfo.version" || string(de.Raw) != "<html>Bad gateway</html>" {
+		t.Errorf("Unexpected error: %s", de)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("upstream is down"))
+	}))
+	defer srv.Close()
+
+	_, err := NewAPI(srv.URL).Version()
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("Expected *HTTPError, got %#v", err)
+	}
+	if he.StatusCode != http.StatusBadGateway || string(he.Body) != "upstream is down" {
+		t.Errorf("Unexpected error: %s", he)
+	}
+}
+
+func TestDecodeNumbersAsStrings(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"item.get": func(params json.RawMessage) interface{} {
+			return []map[string]interface{}{
+				{"itemid": 1, "type": "2", "value_type": 3, "lastclock": "1700000000", "applications": []interface{}{}},
+			}
+		},
+	})
+
+	items, err := api.ItemsGet(Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ItemId != "1" || items[0].Type != ZabbixTrapper || items[0].ValueType != Unsigned || items[0].Lastclock != 1700000000 {
+		t.Errorf("Unexpected items: %#v", items)
+	}
+}
@@ -0,0 +1,62 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+// fakeHandler returns result for JSON-RPC call with given params, or *Error.
+type fakeHandler func(params json.RawMessage) interface{}
+
+// newFakeAPI starts JSON-RPC server answering "apiinfo.version" with version
+// and other methods with handlers. Server is closed on test cleanup.
+func newFakeAPI(t *testing.T, version string, handlers map[string]fakeHandler) *API {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			Id     int32           `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %s", err)
+			return
+		}
+
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req.Id}
+		if strings.ToLower(req.Method) == "apiinfo.version" {
+			res["result"] = version
+		} else if h, ok := handlers[req.Method]; ok {
+			result := h(req.Params)
+			if e, ok := result.(*Error); ok {
+				res["error"] = e
+			} else {
+				res["result"] = result
+			}
+		} else {
+			res["error"] = &Error{Code: -32601, Message: "Method not found.", Data: req.Method}
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(srv.Close)
+
+	api := NewAPI(srv.URL)
+	if err := api.SetAuth("token"); err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+// decodeParams unmarshals params into Params, marking test as failed on error.
+// It is called from server goroutine, so it can't stop the test.
+func decodeParams(t *testing.T, params json.RawMessage) Params {
+	var p Params
+	if err := json.Unmarshal(params, &p); err != nil {
+		t.Errorf("bad params %s: %s", params, err)
+	}
+	return p
+}
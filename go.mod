@@ -2,4 +2,4 @@ module github.com/canghai908/zabbix-go
 
 go 1.18
 
-require github.com/canghai908/reflector v0.0.0-20230322064147-12d37cdd08b2
+require gopkg.in/yaml.v3 v3.0.1
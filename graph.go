@@ -0,0 +1,253 @@
+package zabbix
+
+import (
+	"strconv"
+	"time"
+)
+
+type (
+	GraphType       int
+	YAxisType       int
+	YAxisSide       int
+	DrawType        int
+	CalcFunction    int
+	GraphItemType   int
+	GraphDataSource int
+)
+
+const (
+	GraphNormal   GraphType = 0
+	GraphStacked  GraphType = 1
+	GraphPie      GraphType = 2
+	GraphExploded GraphType = 3
+)
+
+const (
+	YAxisCalculated YAxisType = 0
+	YAxisFixed      YAxisType = 1 // YAxisMin or YAxisMax
+	YAxisItem       YAxisType = 2 // last value of YMinItemId or YMaxItemId
+)
+
+const (
+	YAxisLeft  YAxisSide = 0
+	YAxisRight YAxisSide = 1
+)
+
+const (
+	DrawLine     DrawType = 0
+	DrawFilled   DrawType = 1
+	DrawBold     DrawType = 2
+	DrawDot      DrawType = 3
+	DrawDashed   DrawType = 4
+	DrawGradient DrawType = 5
+)
+
+const (
+	CalcMin  CalcFunction = 1
+	CalcAvg  CalcFunction = 2
+	CalcMax  CalcFunction = 4
+	CalcAll  CalcFunction = 7 // normal and stacked graphs
+	CalcLast CalcFunction = 9 // pie and exploded graphs
+)
+
+const (
+	GraphItemSimple GraphItemType = 0
+	GraphItemSum    GraphItemType = 2 // pie and exploded graphs
+)
+
+// Source of values for GraphDataGet.
+const (
+	GraphDataAuto    GraphDataSource = 0 // trends if period starts before item history storage period
+	GraphDataHistory GraphDataSource = 1
+	GraphDataTrends  GraphDataSource = 2 // history for items without trends
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/graphitem/object
+type GraphItem struct {
+	GraphItemId string        `json:"gitemid,omitempty" zabbix:"readonly"`
+	GraphId     string        `json:"graphid,omitempty" zabbix:"readonly"`
+	ItemId      string        `json:"itemid"`
+	Color       string        `json:"color"` // hex RGB, e.g. "00AA00"
+	CalcFunc    CalcFunction  `json:"calc_fnc,omitempty"`
+	DrawType    DrawType      `json:"drawtype,omitempty"`
+	SortOrder   int           `json:"sortorder,omitempty"`
+	Type        GraphItemType `json:"type,omitempty"`
+	YAxisSide   YAxisSide     `json:"yaxisside,omitempty"`
+}
+
+type GraphItems []GraphItem
+
+func (i *GraphItem) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "graphitem", IdField: "gitemid"}
+}
+func (i *GraphItem) ObjectId() string      { return i.GraphItemId }
+func (i *GraphItem) SetObjectId(id string) { i.GraphItemId = id }
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/graph/object
+type Graph struct {
+	GraphId        string    `json:"graphid,omitempty"`
+	Name           string    `json:"name"`
+	Width          int       `json:"width"`
+	Height         int       `json:"height"`
+	GraphType      GraphType `json:"graphtype,omitempty"`
+	ShowLegend     int       `json:"show_legend,omitempty"`
+	ShowWorkPeriod int       `json:"show_work_period,omitempty"`
+	ShowTriggers   int       `json:"show_triggers,omitempty"`
+	Show3D         int       `json:"show_3d,omitempty"`       // pie and exploded graphs
+	PercentLeft    float64   `json:"percent_left,omitempty"`  // percentile line of left axis
+	PercentRight   float64   `json:"percent_right,omitempty"` // percentile line of right axis
+	YMinType       YAxisType `json:"ymin_type,omitempty"`
+	YMaxType       YAxisType `json:"ymax_type,omitempty"`
+	YAxisMin       float64   `json:"yaxismin,omitempty"`
+	YAxisMax       float64   `json:"yaxismax,omitempty"`
+	YMinItemId     string    `json:"ymin_itemid,omitempty"`
+	YMaxItemId     string    `json:"ymax_itemid,omitempty"`
+	TemplateId     string    `json:"templateid,omitempty" zabbix:"readonly"`
+	Flags          int       `json:"flags,omitempty" zabbix:"readonly"`
+
+	GraphItems GraphItems `json:"gitems,omitempty"` // use "selectGraphItems" to get them
+}
+
+type Graphs []Graph
+
+func (g *Graph) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "graph", IdField: "graphid"} }
+func (g *Graph) ObjectId() string       { return g.GraphId }
+func (g *Graph) SetObjectId(id string)  { g.GraphId = id }
+
+// Wrapper for graph.get: https://www.zabbix.com/documentation/current/manual/api/reference/graph/get
+func (api *API) GraphsGet(params Params) (res Graphs, err error) {
+	return GetObjects[Graph](api, params)
+}
+
+// Gets graph with graph items by Id only if there is exactly 1 matching graph.
+func (api *API) GraphGetById(id string) (res *Graph, err error) {
+	return GetObject[Graph](api, Params{"graphids": id, "selectGraphItems": "extend"})
+}
+
+// Wrapper for graph.create: https://www.zabbix.com/documentation/current/manual/api/reference/graph/create
+func (api *API) GraphsCreate(graphs Graphs) (err error) {
+	return CreateObjects[Graph](api, graphs)
+}
+
+// Wrapper for graph.update: https://www.zabbix.com/documentation/current/manual/api/reference/graph/update
+// Non-empty GraphItems replace existing ones.
+func (api *API) GraphsUpdate(graphs Graphs, fields ...string) (err error) {
+	return UpdateObjects[Graph](api, graphs, fields...)
+}
+
+// Wrapper for graph.delete: https://www.zabbix.com/documentation/current/manual/api/reference/graph/delete
+// Cleans GraphId in all graphs elements if call succeed.
+func (api *API) GraphsDelete(graphs Graphs) (err error) {
+	return DeleteObjects[Graph](api, graphs)
+}
+
+// Wrapper for graph.delete: https://www.zabbix.com/documentation/current/manual/api/reference/graph/delete
+func (api *API) GraphsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Graph](api, ids)
+}
+
+// Wrapper for graphitem.get: https://www.zabbix.com/documentation/current/manual/api/reference/graphitem/get
+func (api *API) GraphItemsGet(params Params) (res GraphItems, err error) {
+	return GetObjects[GraphItem](api, params)
+}
+
+// Values of one graph item for GraphDataGet. Only one of History and Trends is set.
+type GraphSeries struct {
+	GraphItem GraphItem
+	Item      Item
+	History   HistoryRecords
+	Trends    Trends
+}
+
+// Gets graph by Id and values of its items in period [from, till], in order of graph items.
+func (api *API) GraphDataGet(id string, from, till time.Time, source GraphDataSource) (graph *Graph, res []GraphSeries, err error) {
+	if graph, err = api.GraphGetById(id); err != nil {
+		return
+	}
+	ids := make([]string, len(graph.GraphItems))
+	for i, gi := range graph.GraphItems {
+		ids[i] = gi.ItemId
+	}
+	items, err := api.ItemsGet(Params{"itemids": ids, "webitems": true})
+	if err != nil {
+		return nil, nil, err
+	}
+	byId := make(map[string]Item, len(items))
+	for _, item := range items {
+		byId[item.ItemId] = item
+	}
+
+	var history Items
+	var trendIds []string
+	for _, item := range items {
+		if useTrends(&item, from, source) {
+			trendIds = append(trendIds, item.ItemId)
+		} else {
+			history = append(history, item)
+		}
+	}
+	period := Params{"time_from": from.Unix(), "time_till": till.Unix()}
+
+	records := make(map[string]HistoryRecords)
+	if len(history) > 0 {
+		h, err := api.HistoryGetByItems(history, period)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range h {
+			records[r.ItemId] = append(records[r.ItemId], r)
+		}
+	}
+	trends := make(map[string]Trends)
+	if len(trendIds) > 0 {
+		p := Params{"itemids": trendIds}
+		for k, v := range period {
+			p[k] = v
+		}
+		t, err := api.TrendsGet(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range t {
+			trends[r.ItemId] = append(trends[r.ItemId], r)
+		}
+	}
+
+	res = make([]GraphSeries, len(graph.GraphItems))
+	for i, gi := range graph.GraphItems {
+		res[i] = GraphSeries{GraphItem: gi, Item: byId[gi.ItemId], History: records[gi.ItemId], Trends: trends[gi.ItemId]}
+	}
+	return
+}
+
+// Returns true if item values for period starting at from should be taken from trends.
+func useTrends(item *Item, from time.Time, source GraphDataSource) bool {
+	if item.ValueType != Float && item.ValueType != Unsigned {
+		return false
+	}
+	switch source {
+	case GraphDataHistory:
+		return false
+	case GraphDataTrends:
+		return true
+	}
+	d, ok := parseTimePeriod(item.History)
+	return ok && time.Since(from) > d
+}
+
+var timeSuffixes = map[byte]time.Duration{
+	's': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour,
+}
+
+// Parses Zabbix time period like "90d" or "3600". Returns false for macros and other values.
+func parseTimePeriod(s string) (time.Duration, bool) {
+	unit := time.Second
+	if n := len(s); n > 0 && timeSuffixes[s[n-1]] != 0 {
+		unit, s = timeSuffixes[s[n-1]], s[:n-1]
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(v) * unit, true
+}
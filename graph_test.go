@@ -0,0 +1,85 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestGraphCreate(t *testing.T) {
+	expected := `[{"gitems":[{"calc_fnc":2,"color":"00AA00","itemid":"100"},{"color":"AA0000","drawtype":2,"itemid":"101","sortorder":1,"yaxisside":1}],` +
+		`"height":200,"name":"CPU","width":900,"yaxismax":100,"ymax_type":1}]`
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"graph.create": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"graphids": []string{"50"}}
+		},
+	})
+
+	graphs := Graphs{{
+		Name: "CPU", Width: 900, Height: 200, YMaxType: YAxisFixed, YAxisMax: 100,
+		GraphItems: GraphItems{
+			{GraphItemId: "1", ItemId: "100", Color: "00AA00", CalcFunc: CalcAvg},
+			{ItemId: "101", Color: "AA0000", DrawType: DrawBold, SortOrder: 1, YAxisSide: YAxisRight},
+		},
+	}}
+	if err := api.GraphsCreate(graphs); err != nil {
+		t.Fatal(err)
+	}
+	if graphs[0].GraphId != "50" {
+		t.Errorf("unexpected Id %q", graphs[0].GraphId)
+	}
+}
+
+func TestGraphDataGet(t *testing.T) {
+	from := time.Now().Add(-30 * 24 * time.Hour)
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"graph.get": func(raw json.RawMessage) interface{} {
+			return []map[string]interface{}{{
+				"graphid": "50", "name": "CPU", "width": "900", "height": "200",
+				"gitems": []map[string]string{
+					{"gitemid": "2", "itemid": "101", "color": "AA0000", "sortorder": "0"},
+					{"gitemid": "1", "itemid": "100", "color": "00AA00", "sortorder": "1"},
+				},
+			}}
+		},
+		"item.get": func(raw json.RawMessage) interface{} {
+			return []map[string]string{
+				{"itemid": "100", "value_type": "0", "history": "7d"},
+				{"itemid": "101", "value_type": "3", "history": "{$HISTORY}"},
+			}
+		},
+		"history.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["history"] != 3.0 || p["time_from"] != float64(from.Unix()) {
+				t.Errorf("unexpected history params %s", raw)
+			}
+			return []map[string]string{{"itemid": "101", "clock": "1700000000", "ns": "0", "value": "5"}}
+		},
+		"trend.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if ids, _ := p["itemids"].([]interface{}); len(ids) != 1 || ids[0] != "100" {
+				t.Errorf("unexpected trend params %s", raw)
+			}
+			return []map[string]string{{"itemid": "100", "clock": "1699999200", "num": "60", "value_min": "1.5", "value_avg": "2", "value_max": "3"}}
+		},
+	})
+
+	graph, series, err := api.GraphDataGet("50", from, time.Now(), GraphDataAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph.Name != "CPU" || len(series) != 2 {
+		t.Fatalf("unexpected graph %+v, series %+v", graph, series)
+	}
+	if s := series[0]; s.Item.ItemId != "101" || len(s.History) != 1 || s.History[0].Unsigned != 5 || s.Trends != nil {
+		t.Errorf("unexpected history series %+v", s)
+	}
+	if s := series[1]; s.GraphItem.Color != "00AA00" || len(s.Trends) != 1 || s.Trends[0].ValueMin != 1.5 || s.Trends[0].Num != 60 || s.History != nil {
+		t.Errorf("unexpected trend series %+v", s)
+	}
+}
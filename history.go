@@ -1,18 +1,25 @@
 package zabbix
 
 import (
-	"github.com/canghai908/reflector"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
 )
 
 type HistoryItem struct {
 	ItemId string `json:"itemid"`
 	Clock  string `json:"clock"`
 	Value  string `json:"value"`
-	ns     string `json:"ns"`
+	Ns     string `json:"ns"`
 }
 
 type HistoryItems []HistoryItem
 
+// Wrapper for history.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/history/get
+// Returns at most 100 values of float history unless "limit" and "history" are set.
+// Use HistoryGetByItems or HistoryIterate for typed values.
 func (api *API) HistoryGet(params Params) (res HistoryItems, err error) {
 	if _, present := params["output"]; !present {
 		params["output"] = "extend"
@@ -23,11 +30,195 @@ func (api *API) HistoryGet(params Params) (res HistoryItems, err error) {
 	if _, presenth := params["history"]; !presenth {
 		params["history"] = "0"
 	}
-	response, err := api.CallWithError("history.get", params)
+	err = api.callResult("history.get", params, &res)
+	return
+}
+
+// LogEntry holds the fields specific to log history records.
+type LogEntry struct {
+	Timestamp  time.Time // log timestamp, zero if not parsed by the item
+	Source     string    // Windows event log source
+	Severity   int       // Windows event log severity
+	LogEventId int64     // Windows event log event Id
+}
+
+// HistoryRecord is a single history value decoded according to the value type of its item.
+// Only the field matching ValueType is set.
+type HistoryRecord struct {
+	ItemId    string
+	ValueType ValueType
+	Time      time.Time // clock with nanoseconds
+
+	Float    float64   // Float
+	Unsigned uint64    // Unsigned
+	Text     string    // Character, Log and Text
+	Binary   []byte    // Binary
+	Log      *LogEntry // Log
+}
+
+type HistoryRecords []HistoryRecord
+
+// Value returns the typed value of the record: float64, uint64, string or []byte.
+func (r *HistoryRecord) Value() interface{} {
+	switch r.ValueType {
+	case Float:
+		return r.Float
+	case Unsigned:
+		return r.Unsigned
+	case Binary:
+		return r.Binary
+	default:
+		return r.Text
+	}
+}
+
+// String formats the value the way Zabbix returns it.
+func (r *HistoryRecord) String() string {
+	switch r.ValueType {
+	case Float:
+		return strconv.FormatFloat(r.Float, 'f', -1, 64)
+	case Unsigned:
+		return strconv.FormatUint(r.Unsigned, 10)
+	case Binary:
+		return base64.StdEncoding.EncodeToString(r.Binary)
+	default:
+		return r.Text
+	}
+}
+
+// history.get returns all fields as strings, with log fields present only for log history.
+type rawHistoryRecord struct {
+	ItemId     string `json:"itemid"`
+	Clock      string `json:"clock"`
+	Ns         string `json:"ns"`
+	Value      string `json:"value"`
+	Timestamp  string `json:"timestamp"`
+	Source     string `json:"source"`
+	Severity   string `json:"severity"`
+	LogEventId string `json:"logeventid"`
+}
+
+func parseInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func (raw *rawHistoryRecord) record(valueType ValueType) (r HistoryRecord, err error) {
+	r = HistoryRecord{ItemId: raw.ItemId, ValueType: valueType}
+
+	clock, err := parseInt(raw.Clock)
+	if err != nil {
+		return r, fmt.Errorf("bad clock %q for item %s: %s", raw.Clock, raw.ItemId, err)
+	}
+	ns, err := parseInt(raw.Ns)
+	if err != nil {
+		return r, fmt.Errorf("bad ns %q for item %s: %s", raw.Ns, raw.ItemId, err)
+	}
+	r.Time = time.Unix(clock, ns)
+
+	switch valueType {
+	case Float:
+		r.Float, err = strconv.ParseFloat(raw.Value, 64)
+	case Unsigned:
+		r.Unsigned, err = strconv.ParseUint(raw.Value, 10, 64)
+	case Binary:
+		r.Binary, err = base64.StdEncoding.DecodeString(raw.Value)
+	case Log:
+		r.Text = raw.Value
+		r.Log = &LogEntry{Source: raw.Source}
+		var ts, severity int64
+		if ts, err = parseInt(raw.Timestamp); err != nil {
+			break
+		}
+		if ts != 0 {
+			r.Log.Timestamp = time.Unix(ts, 0)
+		}
+		if severity, err = parseInt(raw.Severity); err != nil {
+			break
+		}
+		r.Log.Severity = int(severity)
+		r.Log.LogEventId, err = parseInt(raw.LogEventId)
+	default:
+		r.Text = raw.Value
+	}
+	if err != nil {
+		err = fmt.Errorf("bad value %q for item %s: %s", raw.Value, raw.ItemId, err)
+	}
+	return
+}
+
+// Wrapper for history.get returning typed records of a single value type.
+// The "history" parameter is set from valueType, "output" defaults to "extend".
+func (api *API) HistoryGetTyped(valueType ValueType, params Params) (res HistoryRecords, err error) {
+	p := make(Params, len(params)+2)
+	for k, v := range params {
+		p[k] = v
+	}
+	p["history"] = valueType
+	if _, present := p["output"]; !present {
+		p["output"] = "extend"
+	}
+
+	var raw []rawHistoryRecord
+	err = api.callResult("history.get", p, &raw)
 	if err != nil {
 		return
 	}
 
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+	res = make(HistoryRecords, len(raw))
+	for i := range raw {
+		res[i], err = raw[i].record(valueType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// Gets history of items, querying each value type present in items separately.
+// "itemids" and "history" are set from items, other params are passed as is.
+// Records are sorted by time, then by item Id.
+func (api *API) HistoryGetByItems(items Items, params Params) (res HistoryRecords, err error) {
+	byType := make(map[ValueType][]string)
+	var types []ValueType
+	for _, item := range items {
+		if _, present := byType[item.ValueType]; !present {
+			types = append(types, item.ValueType)
+		}
+		byType[item.ValueType] = append(byType[item.ValueType], item.ItemId)
+	}
+
+	for _, vt := range types {
+		p := make(Params, len(params)+1)
+		for k, v := range params {
+			p[k] = v
+		}
+		p["itemids"] = byType[vt]
+
+		var records HistoryRecords
+		records, err = api.HistoryGetTyped(vt, p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, records...)
+	}
+
+	res.Sort()
 	return
 }
+
+// Sorts records by time, then by item Id.
+func (records HistoryRecords) Sort() {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].less(&records[j])
+	})
+}
+
+func (r *HistoryRecord) less(o *HistoryRecord) bool {
+	if !r.Time.Equal(o.Time) {
+		return r.Time.Before(o.Time)
+	}
+	return r.ItemId < o.ItemId
+}
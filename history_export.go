@@ -0,0 +1,340 @@
+package zabbix
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// HistoryCursor is the position of the last record returned by HistoryIterator.
+// It may be stored (for example, as JSON) and passed back in HistoryExportOptions to resume an export.
+type HistoryCursor struct {
+	Time   time.Time `json:"time"` // clock and ns of record
+	ItemId string    `json:"itemid"`
+	Count  int       `json:"count,omitempty"` // records returned with the same Time and ItemId, 0 is treated as 1
+}
+
+// Returns true if record r is after cursor in iteration order.
+func (c *HistoryCursor) before(r *HistoryRecord) bool {
+	return (&HistoryRecord{Time: c.Time, ItemId: c.ItemId}).less(r)
+}
+
+// Returns true if record r has the same Time and ItemId as cursor.
+func (c *HistoryCursor) at(r *HistoryRecord) bool {
+	return c.Time.Equal(r.Time) && c.ItemId == r.ItemId
+}
+
+// HistoryExportOptions describes history to export with HistoryIterate.
+type HistoryExportOptions struct {
+	Items     Items          // items to export, ItemId and ValueType are used
+	TimeFrom  time.Time      // start of exported period (inclusive), required
+	TimeTill  time.Time      // end of exported period (inclusive), zero means now
+	Window    time.Duration  // period fetched and sorted at once, default is 1 hour
+	ChunkSize int            // item Ids per request, default is 500
+	PageSize  int            // records per request, default is 10000
+	Cursor    *HistoryCursor // if set, only records after cursor are returned
+}
+
+// HistoryIterator pages through history.get and returns records in time order.
+// Only one time window is kept in memory.
+type HistoryIterator struct {
+	api  *API
+	opts HistoryExportOptions
+
+	types []ValueType
+	ids   map[ValueType][]string
+
+	next, till int64 // next window start and end of exported period, Unix time
+	buf        HistoryRecords
+	pos        int
+	record     HistoryRecord
+	cursor     *HistoryCursor
+	resume     *HistoryCursor // cursor passed in options, until the first record after it
+	skipped    int            // records at resume cursor skipped so far
+	err        error
+}
+
+// Returns iterator over history of items described by opts. Requests are made lazily by Next().
+//
+//	it := api.HistoryIterate(HistoryExportOptions{Items: items, TimeFrom: from})
+//	for it.Next() {
+//		r := it.Record()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (api *API) HistoryIterate(opts HistoryExportOptions) *HistoryIterator {
+	if opts.Window <= 0 {
+		opts.Window = time.Hour
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 500
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10000
+	}
+	if opts.TimeTill.IsZero() {
+		opts.TimeTill = time.Now()
+	}
+
+	it := &HistoryIterator{
+		api:    api,
+		opts:   opts,
+		ids:    make(map[ValueType][]string),
+		next:   opts.TimeFrom.Unix(),
+		till:   opts.TimeTill.Unix(),
+		cursor: opts.Cursor,
+		resume: opts.Cursor,
+	}
+	for _, item := range opts.Items {
+		if _, present := it.ids[item.ValueType]; !present {
+			it.types = append(it.types, item.ValueType)
+		}
+		it.ids[item.ValueType] = append(it.ids[item.ValueType], item.ItemId)
+	}
+	if it.cursor != nil && it.cursor.Time.Unix() > it.next {
+		it.next = it.cursor.Time.Unix()
+	}
+	if opts.TimeFrom.IsZero() {
+		it.err = errors.New("HistoryIterate: TimeFrom is required")
+	}
+	return it
+}
+
+// Advances iterator to the next record. Returns false when there are no more records or on error.
+func (it *HistoryIterator) Next() bool {
+	for {
+		for it.pos < len(it.buf) {
+			r := &it.buf[it.pos]
+			it.pos++
+			if it.resume != nil {
+				if it.resume.at(r) && (it.skipped < it.resume.Count || it.skipped == 0) {
+					it.skipped++
+					continue
+				}
+				if !it.resume.at(r) && !it.resume.before(r) {
+					continue
+				}
+				it.resume = nil
+			}
+			it.record = *r
+			count := 1
+			if it.cursor != nil && it.cursor.at(r) {
+				count = it.cursor.Count + 1
+			}
+			it.cursor = &HistoryCursor{Time: r.Time, ItemId: r.ItemId, Count: count}
+			return true
+		}
+
+		if it.err != nil || it.next > it.till {
+			return false
+		}
+
+		from := it.next
+		till := from + int64(it.opts.Window/time.Second) - 1
+		if till < from {
+			till = from
+		}
+		if till > it.till {
+			till = it.till
+		}
+		it.next = till + 1
+
+		it.buf, it.err = it.fetch(from, till)
+		it.pos = 0
+		if it.err != nil {
+			return false
+		}
+	}
+}
+
+// Returns current record.
+func (it *HistoryIterator) Record() HistoryRecord {
+	return it.record
+}
+
+// Returns cursor of current record, or cursor passed in options if Next() was not called yet.
+func (it *HistoryIterator) Cursor() *HistoryCursor {
+	return it.cursor
+}
+
+// Returns the first error encountered by iterator.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+// Fetches all records between from and till (inclusive) and sorts them.
+func (it *HistoryIterator) fetch(from, till int64) (res HistoryRecords, err error) {
+	for _, vt := range it.types {
+		ids := it.ids[vt]
+		for start := 0; start < len(ids); start += it.opts.ChunkSize {
+			end := start + it.opts.ChunkSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+			res, err = it.fetchChunk(res, vt, ids[start:end], from, till)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	// records with the same time and item are ordered by value, so Count of cursor refers to the same records on resume
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].less(&res[j]) || res[j].less(&res[i]) {
+			return res[i].less(&res[j])
+		}
+		return fmt.Sprint(res[i].Value()) < fmt.Sprint(res[j].Value())
+	})
+	return
+}
+
+// Pages through history of ids by clock. The next page starts at the clock of the last record,
+// records with that clock which were already returned are skipped.
+func (it *HistoryIterator) fetchChunk(res HistoryRecords, vt ValueType, ids []string, from, till int64) (HistoryRecords, error) {
+	// records with the same item, time and value are counted, so genuine duplicates are kept
+	type key struct {
+		itemId string
+		clock  int64
+		ns     int
+		value  string
+	}
+	keyOf := func(r *HistoryRecord) key {
+		return key{r.ItemId, r.Time.Unix(), r.Time.Nanosecond(), fmt.Sprint(r.Value())}
+	}
+	seen := make(map[key]int)
+	limit := it.opts.PageSize
+
+	for {
+		records, err := it.api.HistoryGetTyped(vt, Params{
+			"itemids":   ids,
+			"time_from": from,
+			"time_till": till,
+			"sortfield": "clock",
+			"sortorder": "ASC",
+			"limit":     limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range records {
+			r := &records[i]
+			if k := keyOf(r); r.Time.Unix() == from && seen[k] > 0 {
+				seen[k]--
+				continue
+			}
+			res = append(res, *r)
+		}
+		if len(records) < limit {
+			return res, nil
+		}
+
+		last := records[len(records)-1].Time.Unix()
+		if last == from {
+			// whole page has the same clock, fetch it again with bigger limit
+			limit *= 2
+		} else {
+			from = last
+			limit = it.opts.PageSize
+		}
+		seen = make(map[key]int)
+		for i := range records {
+			if r := &records[i]; r.Time.Unix() == last {
+				seen[keyOf(r)]++
+			}
+		}
+	}
+}
+
+// Makes HistoryRecord usable with encoding/json: clock and ns are encoded as Zabbix does,
+// value is encoded as number, string or base64 string.
+func (r HistoryRecord) MarshalJSON() ([]byte, error) {
+	type logJSON struct {
+		Timestamp  int64  `json:"timestamp"`
+		Source     string `json:"source"`
+		Severity   int    `json:"severity"`
+		LogEventId int64  `json:"logeventid"`
+	}
+	v := struct {
+		ItemId    string      `json:"itemid"`
+		ValueType ValueType   `json:"value_type"`
+		Clock     int64       `json:"clock"`
+		Ns        int         `json:"ns"`
+		Value     interface{} `json:"value"`
+		*logJSON
+	}{
+		ItemId:    r.ItemId,
+		ValueType: r.ValueType,
+		Clock:     r.Time.Unix(),
+		Ns:        r.Time.Nanosecond(),
+		Value:     r.Value(),
+	}
+	if r.Log != nil {
+		v.logJSON = &logJSON{Source: r.Log.Source, Severity: r.Log.Severity, LogEventId: r.Log.LogEventId}
+		if !r.Log.Timestamp.IsZero() {
+			v.logJSON.Timestamp = r.Log.Timestamp.Unix()
+		}
+	}
+	return json.Marshal(v)
+}
+
+// Writes all records from iterator to w as newline-delimited JSON objects.
+// Returns number of written records.
+func WriteHistoryNDJSON(w io.Writer, it *HistoryIterator) (n int, err error) {
+	enc := json.NewEncoder(w)
+	for it.Next() {
+		if err = enc.Encode(it.Record()); err != nil {
+			return
+		}
+		n++
+	}
+	err = it.Err()
+	return
+}
+
+// Writes all records from iterator to w as CSV with header row.
+// Log columns are empty for non-log records. Returns number of written records.
+func WriteHistoryCSV(w io.Writer, it *HistoryIterator) (n int, err error) {
+	cw := csv.NewWriter(w)
+	err = cw.Write([]string{"itemid", "clock", "ns", "value_type", "value", "timestamp", "source", "severity", "logeventid"})
+	if err != nil {
+		return
+	}
+
+	for it.Next() {
+		r := it.Record()
+		row := []string{
+			r.ItemId,
+			strconv.FormatInt(r.Time.Unix(), 10),
+			strconv.Itoa(r.Time.Nanosecond()),
+			strconv.Itoa(int(r.ValueType)),
+			r.String(),
+			"", "", "", "",
+		}
+		if r.Log != nil {
+			if !r.Log.Timestamp.IsZero() {
+				row[5] = strconv.FormatInt(r.Log.Timestamp.Unix(), 10)
+			}
+			row[6] = r.Log.Source
+			row[7] = strconv.Itoa(r.Log.Severity)
+			row[8] = strconv.FormatInt(r.Log.LogEventId, 10)
+		}
+		if err = cw.Write(row); err != nil {
+			return
+		}
+		n++
+	}
+
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return
+	}
+	err = it.Err()
+	return
+}
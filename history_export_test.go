@@ -0,0 +1,204 @@
+package zabbix_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+// fakeHistory serves history.get from records honoring itemids, time_from, time_till and limit.
+func fakeHistory(t *testing.T, records []map[string]string, calls *int) fakeHandler {
+	sort.SliceStable(records, func(i, j int) bool { return records[i]["clock"] < records[j]["clock"] })
+	return func(params json.RawMessage) interface{} {
+		*calls++
+		p := decodeParams(t, params)
+		ids := make(map[string]bool)
+		for _, id := range p["itemids"].([]interface{}) {
+			ids[id.(string)] = true
+		}
+		from, till, limit := int(p["time_from"].(float64)), int(p["time_till"].(float64)), int(p["limit"].(float64))
+
+		res := []map[string]string{}
+		for _, r := range records {
+			clock, _ := strconv.Atoi(r["clock"])
+			if ids[r["itemid"]] && clock >= from && clock <= till && len(res) < limit {
+				res = append(res, r)
+			}
+		}
+		return res
+	}
+}
+
+func TestHistoryIterate(t *testing.T) {
+	var records []map[string]string
+	for clock := 10; clock < 20; clock++ {
+		for _, id := range []string{"1", "2"} {
+			records = append(records, map[string]string{"itemid": id, "clock": strconv.Itoa(clock), "ns": "0", "value": strconv.Itoa(clock)})
+		}
+	}
+	// many values in the same second
+	for ns := 1; ns <= 5; ns++ {
+		records = append(records, map[string]string{"itemid": "1", "clock": "15", "ns": strconv.Itoa(ns), "value": "15"})
+	}
+
+	var calls int
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{"history.get": fakeHistory(t, records, &calls)})
+
+	opts := HistoryExportOptions{
+		Items:     Items{{ItemId: "1", ValueType: Unsigned}, {ItemId: "2", ValueType: Unsigned}},
+		TimeFrom:  time.Unix(0, 0),
+		TimeTill:  time.Unix(100, 0),
+		Window:    7 * time.Second,
+		ChunkSize: 1,
+		PageSize:  3,
+	}
+	it := api.HistoryIterate(opts)
+	var got HistoryRecords
+	for it.Next() {
+		got = append(got, it.Record())
+		if len(got) == 12 {
+			break
+		}
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	// resume from cursor
+	opts.Cursor = it.Cursor()
+	it = api.HistoryIterate(opts)
+	for it.Next() {
+		got = append(got, it.Record())
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+
+	if len(got) != len(records) {
+		t.Fatalf("Expected %d records, got %d", len(records), len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i-1].Time.Before(got[i].Time) && !(got[i-1].Time.Equal(got[i].Time) && got[i-1].ItemId < got[i].ItemId) {
+			t.Fatalf("Records %d and %d are out of order: %#v %#v", i-1, i, got[i-1], got[i])
+		}
+	}
+	if calls == 0 {
+		t.Fatal("No calls made")
+	}
+}
+
+func TestHistoryIterateSameTime(t *testing.T) {
+	var calls int
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{"history.get": fakeHistory(t, []map[string]string{
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "1"},
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "1"},
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "2"},
+		{"itemid": "1", "clock": "11", "ns": "0", "value": "3"},
+	}, &calls)})
+
+	it := api.HistoryIterate(HistoryExportOptions{
+		Items:    Items{{ItemId: "1", ValueType: Unsigned}},
+		TimeFrom: time.Unix(0, 0),
+		TimeTill: time.Unix(100, 0),
+		Window:   time.Hour,
+		PageSize: 2,
+	})
+	var values []uint64
+	for it.Next() {
+		r := it.Record()
+		values = append(values, r.Unsigned)
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if !reflect.DeepEqual(values, []uint64{1, 1, 2, 3}) {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestHistoryIterateResumeSameTime(t *testing.T) {
+	var calls int
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{"history.get": fakeHistory(t, []map[string]string{
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "2"},
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "1"},
+		{"itemid": "1", "clock": "10", "ns": "0", "value": "1"},
+		{"itemid": "1", "clock": "10", "ns": "5", "value": "4"},
+		{"itemid": "1", "clock": "11", "ns": "0", "value": "3"},
+	}, &calls)})
+	opts := HistoryExportOptions{
+		Items:    Items{{ItemId: "1", ValueType: Unsigned}},
+		TimeFrom: time.Unix(0, 0),
+		TimeTill: time.Unix(100, 0),
+		PageSize: 2,
+	}
+
+	// stop after each record and resume from cursor stored as JSON
+	var values []uint64
+	for len(values) < 6 {
+		it := api.HistoryIterate(opts)
+		if !it.Next() {
+			if it.Err() != nil {
+				t.Fatal(it.Err())
+			}
+			break
+		}
+		values = append(values, it.Record().Unsigned)
+		b, err := json.Marshal(it.Cursor())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal(b, &opts.Cursor); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(values, []uint64{1, 1, 2, 4, 3}) {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestHistoryIterateNoTimeFrom(t *testing.T) {
+	api := newFakeAPI(t, "7.0.0", nil)
+	it := api.HistoryIterate(HistoryExportOptions{Items: Items{{ItemId: "1", ValueType: Unsigned}}})
+	if it.Next() || it.Err() == nil {
+		t.Error("expected error for zero TimeFrom")
+	}
+}
+
+func TestWriteHistory(t *testing.T) {
+	var calls int
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{"history.get": fakeHistory(t, []map[string]string{
+		{"itemid": "1", "clock": "10", "ns": "5", "value": "1.5"},
+	}, &calls)})
+	opts := HistoryExportOptions{
+		Items:    Items{{ItemId: "1", ValueType: Float}},
+		TimeFrom: time.Unix(0, 0),
+		TimeTill: time.Unix(20, 0),
+	}
+
+	var buf bytes.Buffer
+	n, err := WriteHistoryNDJSON(&buf, api.HistoryIterate(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"itemid":"1","value_type":0,"clock":10,"ns":5,"value":1.5}` + "\n"
+	if n != 1 || buf.String() != expected {
+		t.Errorf("Unexpected NDJSON (%d records):\n%s", n, buf.String())
+	}
+
+	buf.Reset()
+	n, err = WriteHistoryCSV(&buf, api.HistoryIterate(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if n != 1 || len(lines) != 2 || lines[1] != "1,10,5,0,1.5,,,," {
+		t.Errorf("Unexpected CSV (%d records):\n%s", n, buf.String())
+	}
+}
@@ -0,0 +1,119 @@
+package zabbix
+
+import (
+	"fmt"
+	"time"
+)
+
+// HistoryPushValue is a single value for history.push.
+// Item is identified either by ItemId or by Host and Key.
+type HistoryPushValue struct {
+	ItemId string `json:"itemid,omitempty"`
+	Host   string `json:"host,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value"`
+	Clock  int64  `json:"clock,omitempty"` // Unix timestamp, 0 means current time
+	Ns     int64  `json:"ns,omitempty"`
+}
+
+// HistoryPushResult is the result of pushing a single value, in the same order as pushed values.
+type HistoryPushResult struct {
+	ItemId string `json:"itemid"`
+	Error  string `json:"error"`
+}
+
+type HistoryPushResults []HistoryPushResult
+
+// Returns number of values which were not accepted.
+func (results HistoryPushResults) Failed() (n int) {
+	for _, r := range results {
+		if r.Error != "" {
+			n++
+		}
+	}
+	return
+}
+
+// Wrapper for history.push: https://www.zabbix.com/documentation/7.0/manual/api/reference/history/push
+// Requires Zabbix 7.0+. Values which were not accepted have Error set in results; err is only set
+// if the whole call failed.
+func (api *API) HistoryPush(values []HistoryPushValue) (res HistoryPushResults, err error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values to push")
+	}
+	if err = api.require(FeatureHistoryPush); err != nil {
+		return
+	}
+
+	var result struct {
+		Response string             `json:"response"`
+		Data     HistoryPushResults `json:"data"`
+	}
+	err = api.callResult("history.push", values, &result)
+	if err != nil {
+		return
+	}
+	if len(result.Data) != len(values) {
+		return result.Data, &ExpectedMore{len(values), len(result.Data)}
+	}
+	return result.Data, nil
+}
+
+// DataSender is implemented by Sender and PushSender, so code can switch between
+// Zabbix Sender Protocol and history.push.
+type DataSender interface {
+	Send(data SenderData) (*SenderResponse, error)
+	SendBatch(data []SenderData) (*SenderResponse, error)
+}
+
+var (
+	_ DataSender = (*Sender)(nil)
+	_ DataSender = (*PushSender)(nil)
+)
+
+// PushSender sends SenderData using history.push API method (Zabbix 7.0+) over the same
+// connection and authentication as API.
+type PushSender struct {
+	API *API
+}
+
+// NewPushSender creates a new PushSender instance
+func NewPushSender(api *API) *PushSender {
+	return &PushSender{API: api}
+}
+
+// Send sends a single data item using history.push
+func (s *PushSender) Send(data SenderData) (*SenderResponse, error) {
+	return s.SendBatch([]SenderData{data})
+}
+
+// SendBatch sends multiple data items using history.push.
+// Response info has the same format as the one of Zabbix Server trapper.
+func (s *PushSender) SendBatch(data []SenderData) (*SenderResponse, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data to send")
+	}
+
+	values := make([]HistoryPushValue, len(data))
+	for i, d := range data {
+		values[i] = HistoryPushValue{Host: d.Host, Key: d.Key, Value: d.Value, Clock: d.Clock, Ns: d.Ns}
+	}
+
+	start := time.Now()
+	results, err := s.API.HistoryPush(values)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			s.API.printf("history.push: item %s: %s", r.ItemId, r.Error)
+		}
+	}
+
+	failed := results.Failed()
+	return &SenderResponse{
+		Response: "success",
+		Info: fmt.Sprintf("processed: %d; failed: %d; total: %d; seconds spent: %f",
+			len(results)-failed, failed, len(results), time.Since(start).Seconds()),
+	}, nil
+}
@@ -0,0 +1,48 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestHistoryPush(t *testing.T) {
+	var pushed []HistoryPushValue
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"history.push": func(params json.RawMessage) interface{} {
+			if err := json.Unmarshal(params, &pushed); err != nil {
+				t.Error(err)
+				return nil
+			}
+			return map[string]interface{}{
+				"response": "success",
+				"data":     []map[string]string{{"itemid": "10"}, {"error": "Item is disabled."}},
+			}
+		},
+	})
+
+	var sender DataSender = NewPushSender(api)
+	res, err := sender.SendBatch([]SenderData{
+		{Host: "host", Key: "key1", Value: "1", Clock: 100, Ns: 5},
+		{Host: "host", Key: "key2", Value: "2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pushed) != 2 || pushed[0] != (HistoryPushValue{Host: "host", Key: "key1", Value: "1", Clock: 100, Ns: 5}) {
+		t.Errorf("Unexpected pushed values: %#v", pushed)
+	}
+	if res.Response != "success" || !strings.HasPrefix(res.Info, "processed: 1; failed: 1; total: 2;") {
+		t.Errorf("Unexpected response: %#v", res)
+	}
+}
+
+func TestHistoryPushOldVersion(t *testing.T) {
+	api := newFakeAPI(t, "6.4.0", nil)
+	_, err := api.HistoryPush([]HistoryPushValue{{ItemId: "1", Value: "1"}})
+	if err == nil {
+		t.Fatal("Expected error for Zabbix 6.4")
+	}
+}
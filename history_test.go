@@ -0,0 +1,76 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestHistoryGetByItems(t *testing.T) {
+	var calls []Params
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"history.get": func(params json.RawMessage) interface{} {
+			p := decodeParams(t, params)
+			calls = append(calls, p)
+			switch p["history"] {
+			case float64(Float):
+				return []map[string]string{{"itemid": "1", "clock": "100", "ns": "500", "value": "0.25"}}
+			case float64(Log):
+				return []map[string]string{{
+					"itemid": "2", "clock": "99", "ns": "0", "value": "line",
+					"timestamp": "98", "source": "app", "severity": "4", "logeventid": "42",
+				}}
+			case float64(Unsigned):
+				return []map[string]string{{"itemid": "3", "clock": "100", "ns": "500", "value": "18446744073709551615"}}
+			}
+			t.Errorf("unexpected history type: %v", p["history"])
+			return []interface{}{}
+		},
+	})
+
+	items := Items{
+		{ItemId: "1", ValueType: Float},
+		{ItemId: "2", ValueType: Log},
+		{ItemId: "3", ValueType: Unsigned},
+	}
+	records, err := api.HistoryGetByItems(items, Params{"time_from": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 calls, got %d", len(calls))
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %#v", records)
+	}
+
+	log := records[0]
+	if log.ItemId != "2" || log.Text != "line" || log.Log == nil {
+		t.Fatalf("Bad log record: %#v", log)
+	}
+	if log.Log.Source != "app" || log.Log.Severity != 4 || log.Log.LogEventId != 42 || !log.Log.Timestamp.Equal(time.Unix(98, 0)) {
+		t.Errorf("Bad log entry: %#v", log.Log)
+	}
+
+	if records[1].ItemId != "1" || records[1].Float != 0.25 || !records[1].Time.Equal(time.Unix(100, 500)) {
+		t.Errorf("Bad float record: %#v", records[1])
+	}
+	if records[2].ItemId != "3" || records[2].Unsigned != 18446744073709551615 {
+		t.Errorf("Bad unsigned record: %#v", records[2])
+	}
+}
+
+func TestHistoryGetTypedBadValue(t *testing.T) {
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"history.get": func(params json.RawMessage) interface{} {
+			return []map[string]string{{"itemid": "1", "clock": "100", "ns": "0", "value": "abc"}}
+		},
+	})
+
+	_, err := api.HistoryGetTyped(Float, Params{"itemids": "1"})
+	if err == nil {
+		t.Fatal("Expected error for non-numeric float value")
+	}
+}
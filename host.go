@@ -1,49 +1,150 @@
 package zabbix
 
-import (
-	"github.com/canghai908/reflector"
-)
-
 type (
-	AvailableType int
-	StatusType    int
+	AvailableType     int
+	StatusType        int
+	InventoryMode     int
+	MonitoredBy       int
+	MaintenanceStatus int
+	MaintenanceType   int
+	TLSConnection     int
 )
 
 const (
-	Available   AvailableType = 1
-	Unavailable AvailableType = 2
+	AvailableUnknown AvailableType = 0
+	Available        AvailableType = 1
+	Unavailable      AvailableType = 2
 
 	Monitored   StatusType = 0
 	Unmonitored StatusType = 1
+
+	InventoryDisabled  InventoryMode = -1
+	InventoryManual    InventoryMode = 0
+	InventoryAutomatic InventoryMode = 1
+
+	MonitoredByServer     MonitoredBy = 0
+	MonitoredByProxy      MonitoredBy = 1
+	MonitoredByProxyGroup MonitoredBy = 2
+
+	NoMaintenance MaintenanceStatus = 0
+	InMaintenance MaintenanceStatus = 1
+
+	MaintenanceWithData MaintenanceType = 0
+	MaintenanceNoData   MaintenanceType = 1
+
+	// Bitmask values for TLSAccept, single values for TLSConnect.
+	TLSNoEncryption TLSConnection = 1
+	TLSPSK          TLSConnection = 2
+	TLSCertificate  TLSConnection = 4
 )
 
-// https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/definitions
+// https://www.zabbix.com/documentation/current/manual/api/reference/host/object
 type Host struct {
-	HostId    string        `json:"hostid,omitempty"`
-	Host      string        `json:"host"`
-	Available AvailableType `json:"available"`
-	Error     string        `json:"error"`
-	Name      string        `json:"name"`
-	Status    StatusType    `json:"status"`
+	HostId      string        `json:"hostid,omitempty"`
+	Host        string        `json:"host"`
+	Available   AvailableType `json:"available" zabbix:"readonly"` // before Zabbix 5.4, later taken from main agent interface
+	Error       string        `json:"error" zabbix:"readonly"`     // before Zabbix 5.4
+	Name        string        `json:"name"`
+	Status      StatusType    `json:"status"`
+	Description string        `json:"description,omitempty"`
+
+	InventoryMode *InventoryMode `json:"inventory_mode,omitempty"` // nil to keep current or default mode
+	ProxyId       string         `json:"proxyid,omitempty"`        // "proxy_hostid" before Zabbix 7.0
+	ProxyGroupId  string         `json:"proxy_groupid,omitempty"`  // Zabbix 7.0+
+	MonitoredBy   MonitoredBy    `json:"monitored_by,omitempty"`   // Zabbix 7.0+
+
+	MaintenanceStatus MaintenanceStatus `json:"maintenance_status,omitempty" zabbix:"readonly"`
+	MaintenanceType   MaintenanceType   `json:"maintenance_type,omitempty" zabbix:"readonly"`
+	MaintenanceId     string            `json:"maintenanceid,omitempty" zabbix:"readonly"`
+	MaintenanceFrom   int64             `json:"maintenance_from,omitempty" zabbix:"readonly"`
+
+	TLSConnect     TLSConnection `json:"tls_connect,omitempty"`
+	TLSAccept      TLSConnection `json:"tls_accept,omitempty"`
+	TLSIssuer      string        `json:"tls_issuer,omitempty"`
+	TLSSubject     string        `json:"tls_subject,omitempty"`
+	TLSPSKIdentity string        `json:"tls_psk_identity,omitempty"` // write-only since Zabbix 5.4
+	TLSPSK         string        `json:"tls_psk,omitempty"`          // write-only since Zabbix 5.4
+
+	// Fields below used when creating and updating hosts, and filled by host.get with select* parameters
+	GroupIds    HostGroupIds   `json:"groups,omitempty"`    // selectGroups or selectHostGroups
+	TemplateIds TemplateIds    `json:"templates,omitempty"` // not returned, see ParentTemplates
+	Interfaces  HostInterfaces `json:"interfaces,omitempty"`
+	Tags        Tags           `json:"tags,omitempty"`
+	Macros      UserMacros     `json:"macros,omitempty"`
+	Inventory   HostInventory  `json:"inventory,omitempty"`
 
-	// Fields below used only when creating hosts
-	GroupIds   HostGroupIds   `json:"groups,omitempty"`
-	Interfaces HostInterfaces `json:"interfaces,omitempty"`
+	// Fields below filled only by host.get with select* parameters
+	Groups          HostGroups `json:"-"` // selectGroups or selectHostGroups (Zabbix 6.2+)
+	ParentTemplates Templates  `json:"parentTemplates,omitempty" zabbix:"readonly"`
 }
 
+// Host inventory fields by name, e.g. "os" or "serialno_a".
+type HostInventory map[string]string
+
 type Hosts []Host
 
-// Wrapper for host.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/get
-func (api *API) HostsGet(params Params) (res Hosts, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
+type HostId struct {
+	HostId string `json:"hostid"`
+}
+
+type HostIds []HostId
+
+// Decodes host across Zabbix versions: fills ProxyId from "proxy_hostid" ("0" of hosts without proxy
+// or proxy group is decoded as empty ProxyId and ProxyGroupId on all versions), Groups and GroupIds from
+// "groups" or "hostgroups", and Available from main agent interface if host has no "available" field.
+func (h *Host) UnmarshalJSON(b []byte) error {
+	type host Host
+	var v struct {
+		host
+		Available   *AvailableType `json:"available"`
+		ProxyHostId string         `json:"proxy_hostid"`
+		Groups      HostGroups     `json:"groups"`
+		HostGroups  HostGroups     `json:"hostgroups"`
 	}
-	response, err := api.CallWithError("host.get", params)
-	if err != nil {
-		return
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
 	}
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
-	return
+
+	*h = Host(v.host)
+	if h.ProxyId == "0" {
+		h.ProxyId = ""
+	}
+	if h.ProxyGroupId == "0" {
+		h.ProxyGroupId = ""
+	}
+	if h.ProxyId == "" && v.ProxyHostId != "0" {
+		h.ProxyId = v.ProxyHostId
+	}
+	h.Groups = v.Groups
+	if v.HostGroups != nil {
+		h.Groups = v.HostGroups
+	}
+	if h.Groups != nil {
+		h.GroupIds = make(HostGroupIds, len(h.Groups))
+		for i, g := range h.Groups {
+			h.GroupIds[i].GroupId = g.GroupId
+		}
+	}
+
+	if v.Available != nil {
+		h.Available = *v.Available
+	} else {
+		for _, iface := range h.Interfaces {
+			if iface.Type == Agent && iface.Main == 1 {
+				h.Available = iface.Available
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Host) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "host", IdField: "hostid"} }
+func (h *Host) ObjectId() string       { return h.HostId }
+func (h *Host) SetObjectId(id string)  { h.HostId = id }
+
+// Wrapper for host.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/get
+func (api *API) HostsGet(params Params) (res Hosts, err error) {
+	return GetObjects[Host](api, params)
 }
 
 // Gets hosts by host group Ids.
@@ -62,49 +163,87 @@ func (api *API) HostsGetByHostGroups(hostGroups HostGroups) (res Hosts, err erro
 
 // Gets host by Id only if there is exactly 1 matching host.
 func (api *API) HostGetById(id string) (res *Host, err error) {
-	hosts, err := api.HostsGet(Params{"hostids": id})
-	if err != nil {
-		return
-	}
-
-	if len(hosts) == 1 {
-		res = &hosts[0]
-	} else {
-		e := ExpectedOneResult(len(hosts))
-		err = &e
-	}
-	return
+	return GetObject[Host](api, Params{"hostids": id})
 }
 
 // Gets host by Host only if there is exactly 1 matching host.
 func (api *API) HostGetByHost(host string) (res *Host, err error) {
-	hosts, err := api.HostsGet(Params{"filter": map[string]string{"host": host}})
-	if err != nil {
-		return
-	}
-
-	if len(hosts) == 1 {
-		res = &hosts[0]
-	} else {
-		e := ExpectedOneResult(len(hosts))
-		err = &e
-	}
-	return
+	return GetObject[Host](api, Params{"filter": map[string]string{"host": host}})
 }
 
 // Wrapper for host.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/create
 func (api *API) HostsCreate(hosts Hosts) (err error) {
-	response, err := api.CallWithError("host.create", hosts)
-	if err != nil {
-		return
+	return CreateObjects[Host](api, hosts)
+}
+
+// Wrapper for host.update: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/update
+// Sends only HostId, non-zero fields and fields listed by JSON name, e.g. "status" to set Monitored.
+func (api *API) HostsUpdate(hosts Hosts, fields ...string) (err error) {
+	return UpdateObjects[Host](api, hosts, fields...)
+}
+
+// HostMass holds objects added, replaced or removed by host.massadd, host.massupdate and host.massremove.
+// Only non-empty fields are sent.
+type HostMass struct {
+	GroupIds    []string
+	TemplateIds []string
+	Interfaces  HostInterfaces
+
+	// Extra raw parameters, e.g. "status" for host.massupdate or "templateids_clear" for host.massremove.
+	Params Params
+}
+
+func idObjects(key string, ids []string) []map[string]string {
+	res := make([]map[string]string, len(ids))
+	for i, id := range ids {
+		res[i] = map[string]string{key: id}
+	}
+	return res
+}
+
+// Returns parameters for host.massadd and host.massupdate, which take objects with Ids.
+func (m *HostMass) objectParams(hostIds []string) Params {
+	p := Params{"hosts": idObjects("hostid", hostIds)}
+	if len(m.GroupIds) > 0 {
+		p["groups"] = idObjects("groupid", m.GroupIds)
+	}
+	if len(m.TemplateIds) > 0 {
+		p["templates"] = idObjects("templateid", m.TemplateIds)
+	}
+	if len(m.Interfaces) > 0 {
+		p["interfaces"] = m.Interfaces
+	}
+	for k, v := range m.Params {
+		p[k] = v
 	}
+	return p
+}
+
+// Wrapper for host.massadd: https://www.zabbix.com/documentation/current/manual/api/reference/host/massadd
+// Adds groups, templates and interfaces to all hosts.
+func (api *API) HostsMassAdd(hostIds []string, m HostMass) (err error) {
+	return api.callMass("host.massadd", "hostids", hostIds, m.objectParams(hostIds))
+}
+
+// Wrapper for host.massupdate: https://www.zabbix.com/documentation/current/manual/api/reference/host/massupdate
+// Replaces groups, templates and interfaces of all hosts.
+func (api *API) HostsMassUpdate(hostIds []string, m HostMass) (err error) {
+	return api.callMass("host.massupdate", "hostids", hostIds, m.objectParams(hostIds))
+}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	for i, id := range hostids {
-		hosts[i].HostId = id.(string)
+// Wrapper for host.massremove: https://www.zabbix.com/documentation/current/manual/api/reference/host/massremove
+// Removes groups, templates and interfaces from all hosts.
+func (api *API) HostsMassRemove(hostIds []string, m HostMass) (err error) {
+	p := Params{"hostids": hostIds}
+	p.setStrings("groupids", m.GroupIds)
+	p.setStrings("templateids", m.TemplateIds)
+	if len(m.Interfaces) > 0 {
+		p["interfaces"] = m.Interfaces
 	}
-	return
+	for k, v := range m.Params {
+		p[k] = v
+	}
+	return api.callMass("host.massremove", "hostids", hostIds, p)
 }
 
 // Wrapper for host.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/delete
@@ -125,27 +264,14 @@ func (api *API) HostsDelete(hosts Hosts) (err error) {
 }
 
 // Wrapper for host.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/delete
+// Zabbix before 2.4 takes objects with Ids instead of Ids.
 func (api *API) HostsDeleteByIds(ids []string) (err error) {
-	hostIds := make([]map[string]string, len(ids))
-	for i, id := range ids {
-		hostIds[i] = map[string]string{"hostid": id}
-	}
-
-	response, err := api.CallWithError("host.delete", hostIds)
-	if err != nil {
-		// Zabbix 2.4 uses new syntax only
-		if e, ok := err.(*Error); ok && e.Code == -32500 {
-			response, err = api.CallWithError("host.delete", ids)
-		}
-	}
+	v, err := api.version()
 	if err != nil {
 		return
 	}
-
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	if len(ids) != len(hostids) {
-		err = &ExpectedMore{len(ids), len(hostids)}
+	if v.Supports(FeatureHostDeleteIds) {
+		return DeleteObjectsByIds[Host](api, ids)
 	}
-	return
+	return api.callMass("host.delete", "hostids", ids, idObjects("hostid", ids))
 }
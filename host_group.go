@@ -1,9 +1,5 @@
 package zabbix
 
-import (
-	"github.com/canghai908/reflector"
-)
-
 type (
 	InternalType int
 )
@@ -17,11 +13,17 @@ const (
 type HostGroup struct {
 	GroupId  string       `json:"groupid,omitempty"`
 	Name     string       `json:"name"`
-	Internal InternalType `json:"internal,omitempty"`
+	Internal InternalType `json:"internal,omitempty" zabbix:"readonly"`
 }
 
 type HostGroups []HostGroup
 
+func (g *HostGroup) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "hostgroup", IdField: "groupid"}
+}
+func (g *HostGroup) ObjectId() string      { return g.GroupId }
+func (g *HostGroup) SetObjectId(id string) { g.GroupId = id }
+
 type HostGroupId struct {
 	GroupId string `json:"groupid"`
 }
@@ -30,77 +32,49 @@ type HostGroupIds []HostGroupId
 
 // Wrapper for hostgroup.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/get
 func (api *API) HostGroupsGet(params Params) (res HostGroups, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
-	}
-	response, err := api.CallWithError("hostgroup.get", params)
-	if err != nil {
-		return
-	}
-
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
-	return
+	return GetObjects[HostGroup](api, params)
 }
 
 // Gets host group by Id only if there is exactly 1 matching host group.
 func (api *API) HostGroupGetById(id string) (res *HostGroup, err error) {
-	groups, err := api.HostGroupsGet(Params{"groupids": id})
-	if err != nil {
-		return
-	}
-
-	if len(groups) == 1 {
-		res = &groups[0]
-	} else {
-		e := ExpectedOneResult(len(groups))
-		err = &e
-	}
-	return
+	return GetObject[HostGroup](api, Params{"groupids": id})
 }
 
 // Wrapper for hostgroup.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/create
 func (api *API) HostGroupsCreate(hostGroups HostGroups) (err error) {
-	response, err := api.CallWithError("hostgroup.create", hostGroups)
-	if err != nil {
-		return
-	}
+	return CreateObjects[HostGroup](api, hostGroups)
+}
 
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	for i, id := range groupids {
-		hostGroups[i].GroupId = id.(string)
-	}
-	return
+// Wrapper for hostgroup.update: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/update
+func (api *API) HostGroupsUpdate(hostGroups HostGroups, fields ...string) (err error) {
+	return UpdateObjects[HostGroup](api, hostGroups, fields...)
+}
+
+// Wrapper for hostgroup.massadd: https://www.zabbix.com/documentation/current/manual/api/reference/hostgroup/massadd
+// Adds hosts to all host groups.
+func (api *API) HostGroupsMassAdd(groupIds, hostIds []string) (err error) {
+	return api.callMass("hostgroup.massadd", "groupids", groupIds, Params{
+		"groups": idObjects("groupid", groupIds),
+		"hosts":  idObjects("hostid", hostIds),
+	})
+}
+
+// Wrapper for hostgroup.massremove: https://www.zabbix.com/documentation/current/manual/api/reference/hostgroup/massremove
+// Removes hosts from all host groups.
+func (api *API) HostGroupsMassRemove(groupIds, hostIds []string) (err error) {
+	return api.callMass("hostgroup.massremove", "groupids", groupIds, Params{
+		"groupids": groupIds,
+		"hostids":  hostIds,
+	})
 }
 
 // Wrapper for hostgroup.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/delete
 // Cleans GroupId in all hostGroups elements if call succeed.
 func (api *API) HostGroupsDelete(hostGroups HostGroups) (err error) {
-	ids := make([]string, len(hostGroups))
-	for i, group := range hostGroups {
-		ids[i] = group.GroupId
-	}
-
-	err = api.HostGroupsDeleteByIds(ids)
-	if err == nil {
-		for i := range hostGroups {
-			hostGroups[i].GroupId = ""
-		}
-	}
-	return
+	return DeleteObjects[HostGroup](api, hostGroups)
 }
 
 // Wrapper for hostgroup.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/delete
 func (api *API) HostGroupsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("hostgroup.delete", ids)
-	if err != nil {
-		return
-	}
-
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	if len(ids) != len(groupids) {
-		err = &ExpectedMore{len(ids), len(groupids)}
-	}
-	return
+	return DeleteObjectsByIds[HostGroup](api, ids)
 }
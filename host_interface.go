@@ -1,11 +1,9 @@
 package zabbix
 
-import (
-	"github.com/canghai908/reflector"
-)
-
 type (
-	InterfaceType int
+	InterfaceType     int
+	SNMPVersion       int
+	SNMPSecurityLevel int
 )
 
 const (
@@ -13,9 +11,17 @@ const (
 	SNMP  InterfaceType = 2
 	IPMI  InterfaceType = 3
 	JMX   InterfaceType = 4
+
+	SNMPv1  SNMPVersion = 1
+	SNMPv2c SNMPVersion = 2
+	SNMPv3  SNMPVersion = 3
+
+	NoAuthNoPriv SNMPSecurityLevel = 0
+	AuthNoPriv   SNMPSecurityLevel = 1
+	AuthPriv     SNMPSecurityLevel = 2
 )
 
-// https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostinterface/definitions
+// https://www.zabbix.com/documentation/current/manual/api/reference/hostinterface/object
 type HostInterface struct {
 	HostId      string        `json:"hostid"`
 	InterfaceId string        `json:"interfaceid"`
@@ -25,23 +31,38 @@ type HostInterface struct {
 	Port        string        `json:"port"`
 	Type        InterfaceType `json:"type"`
 	UseIP       int           `json:"useip"`
+
+	Available AvailableType     `json:"available,omitempty" zabbix:"readonly"` // Zabbix 5.4+
+	Error     string            `json:"error,omitempty" zabbix:"readonly"`     // Zabbix 5.4+
+	Details   *InterfaceDetails `json:"details,omitempty"`                     // SNMP interfaces, Zabbix 5.0+
+}
+
+// SNMP interface details: https://www.zabbix.com/documentation/current/manual/api/reference/hostinterface/object#details
+type InterfaceDetails struct {
+	Version        SNMPVersion       `json:"version" yaml:"version"`
+	Bulk           int               `json:"bulk" yaml:"bulk"`
+	Community      string            `json:"community,omitempty" yaml:"community,omitempty"`             // SNMPv1 and SNMPv2c
+	MaxRepetitions int               `json:"max_repetitions,omitempty" yaml:"max_repetitions,omitempty"` // SNMPv2c and SNMPv3, Zabbix 6.4+
+	SecurityName   string            `json:"securityname,omitempty" yaml:"securityname,omitempty"`
+	SecurityLevel  SNMPSecurityLevel `json:"securitylevel,omitempty" yaml:"securitylevel,omitempty"`
+	AuthPassphrase string            `json:"authpassphrase,omitempty" yaml:"authpassphrase,omitempty"`
+	PrivPassphrase string            `json:"privpassphrase,omitempty" yaml:"privpassphrase,omitempty"`
+	AuthProtocol   int               `json:"authprotocol,omitempty" yaml:"authprotocol,omitempty"`
+	PrivProtocol   int               `json:"privprotocol,omitempty" yaml:"privprotocol,omitempty"`
+	ContextName    string            `json:"contextname,omitempty" yaml:"contextname,omitempty"`
 }
 
 type HostInterfaces []HostInterface
 
+func (i *HostInterface) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "hostinterface", IdField: "interfaceid"}
+}
+func (i *HostInterface) ObjectId() string      { return i.InterfaceId }
+func (i *HostInterface) SetObjectId(id string) { i.InterfaceId = id }
+
 func (api *API) HostInterfacesGet(params Params) (res HostInterfaces, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
-	}
 	if _, presentl := params["limit"]; !presentl {
 		params["limit"] = "100"
 	}
-
-	response, err := api.CallWithError("hostinterface.get", params)
-	if err != nil {
-		return
-	}
-
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
-	return
+	return GetObjects[HostInterface](api, params)
 }
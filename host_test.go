@@ -1,6 +1,7 @@
 package zabbix_test
 
 import (
+	"encoding/json"
 	"fmt"
 	. "github.com/canghai908/zabbix-go"
 	"math/rand"
@@ -87,3 +88,98 @@ func TestHosts(t *testing.T) {
 		t.Errorf("Bad hosts: %#v", hosts)
 	}
 }
+
+func TestHostCreateInventoryMode(t *testing.T) {
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"host.create": func(params json.RawMessage) interface{} {
+			var p []map[string]interface{}
+			if err := json.Unmarshal(params, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			if mode, ok := p[0]["inventory_mode"]; !ok || mode != 0.0 {
+				t.Errorf("Expected manual inventory mode, got %s", params)
+			}
+			if _, ok := p[1]["inventory_mode"]; ok {
+				t.Errorf("Unexpected inventory mode of second host: %s", params)
+			}
+			return map[string]interface{}{"hostids": []string{"1", "2"}}
+		},
+	})
+
+	manual := InventoryManual
+	if err := api.HostsCreate(Hosts{{Host: "a", InventoryMode: &manual}, {Host: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHostDecodeVersions(t *testing.T) {
+	hosts := map[string]string{
+		"5.0.0": `{"hostid":"1","host":"h","available":"1","proxy_hostid":"7",
+			"groups":[{"groupid":"4","name":"Linux"}],"inventory":[],
+			"interfaces":[{"interfaceid":"2","type":"2","main":"1","details":[]}]}`,
+		"7.0.0": `{"hostid":"1","host":"h","proxyid":"7","monitored_by":"1",
+			"hostgroups":[{"groupid":"4","name":"Linux"}],"inventory":{"os":"Linux"},
+			"tags":[{"tag":"env","value":"prod"}],"macros":[{"macro":"{$A}","type":"1"}],
+			"parentTemplates":[{"templateid":"9","host":"T","name":"T"}],
+			"interfaces":[{"interfaceid":"2","type":"1","main":"1","available":"1"},
+				{"interfaceid":"3","type":"2","main":"1","available":"2","details":{"version":"3","bulk":"1","securitylevel":"2"}}]}`,
+	}
+
+	for version, host := range hosts {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"host.get": func(params json.RawMessage) interface{} {
+				return []json.RawMessage{json.RawMessage(host)}
+			},
+		})
+
+		h, err := api.HostGetById("1")
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if h.ProxyId != "7" || h.Available != Available {
+			t.Errorf("%s: unexpected host: %#v", version, h)
+		}
+		if len(h.Groups) != 1 || h.Groups[0].Name != "Linux" || !reflect.DeepEqual(h.GroupIds, HostGroupIds{{"4"}}) {
+			t.Errorf("%s: unexpected groups: %#v %#v", version, h.Groups, h.GroupIds)
+		}
+
+		if version == "7.0.0" {
+			if v, _ := h.Tags.Get("env"); v != "prod" || h.Inventory["os"] != "Linux" || h.Macros[0].Type != SecretMacro {
+				t.Errorf("%s: unexpected sub-objects: %#v", version, h)
+			}
+			if h.ParentTemplates[0].TemplateId != "9" || h.MonitoredBy != MonitoredByProxy {
+				t.Errorf("%s: unexpected host: %#v", version, h)
+			}
+			details := h.Interfaces[1].Details
+			if details == nil || details.Version != SNMPv3 || details.Bulk != 1 || details.SecurityLevel != AuthPriv {
+				t.Errorf("%s: unexpected details: %#v", version, details)
+			}
+		} else if h.Interfaces[0].Details != nil {
+			t.Errorf("%s: unexpected details: %#v", version, h.Interfaces[0].Details)
+		}
+	}
+}
+
+func TestHostDecodeNoProxy(t *testing.T) {
+	hosts := map[string]string{
+		"6.0.0": `{"hostid":"1","host":"h","proxy_hostid":"0"}`,
+		"7.0.0": `{"hostid":"1","host":"h","proxyid":"0","proxy_groupid":"0","monitored_by":"0"}`,
+	}
+
+	for version, host := range hosts {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"host.get": func(params json.RawMessage) interface{} {
+				return []json.RawMessage{json.RawMessage(host)}
+			},
+		})
+
+		h, err := api.HostGetById("1")
+		if err != nil {
+			t.Fatalf("%s: %s", version, err)
+		}
+		if h.ProxyId != "" || h.ProxyGroupId != "" || h.MonitoredBy != MonitoredByServer {
+			t.Errorf("%s: unexpected host: %#v", version, h)
+		}
+	}
+}
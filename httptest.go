@@ -0,0 +1,302 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+type (
+	HttpTestStatus     int
+	HttpAuthentication int
+	RetrieveMode       int
+	PostType           int
+)
+
+const (
+	HttpTestEnabled  HttpTestStatus = 0
+	HttpTestDisabled HttpTestStatus = 1
+)
+
+const (
+	HttpAuthNone     HttpAuthentication = 0
+	HttpAuthBasic    HttpAuthentication = 1
+	HttpAuthNTLM     HttpAuthentication = 2
+	HttpAuthKerberos HttpAuthentication = 3 // Zabbix 4.0+
+	HttpAuthDigest   HttpAuthentication = 4 // Zabbix 5.2+
+)
+
+const (
+	RetrieveBody        RetrieveMode = 0
+	RetrieveHeaders     RetrieveMode = 1
+	RetrieveBodyHeaders RetrieveMode = 2
+)
+
+const (
+	PostForm PostType = 0 // Posts are sent as form fields
+	PostRaw  PostType = 1 // RawPost is sent as is
+)
+
+// Name and value of variable, header, query or form field.
+type HttpField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HttpFields []HttpField
+
+// Decodes array of fields, or "name=value" lines used before Zabbix 4.0.
+func (f *HttpFields) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 || b[0] != '"' {
+		var v []HttpField
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		*f = v
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*f = nil
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(line, "=")
+		*f = append(*f, HttpField{Name: name, Value: value})
+	}
+	return nil
+}
+
+// Returns fields as "name=value" lines used before Zabbix 4.0.
+func (f HttpFields) lines() string {
+	lines := make([]string, len(f))
+	for i, field := range f {
+		lines[i] = field.Name + "=" + field.Value
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/httptest/object#scenario-step
+type HttpStep struct {
+	HttpStepId      string       `json:"httpstepid,omitempty"`
+	Name            string       `json:"name"`
+	No              int          `json:"no"` // order of step, starting from 1
+	URL             string       `json:"url"`
+	Timeout         string       `json:"timeout,omitempty"`          // e.g. "15s"
+	Required        string       `json:"required,omitempty"`         // text which must be present in response
+	StatusCodes     string       `json:"status_codes,omitempty"`     // e.g. "200,201,210-299"
+	FollowRedirects *int         `json:"follow_redirects,omitempty"` // 1 to follow redirects, 0 not to, nil for default (1)
+	RetrieveMode    RetrieveMode `json:"retrieve_mode,omitempty"`
+	QueryFields     HttpFields   `json:"query_fields,omitempty"` // Zabbix 4.0+
+	PostType        PostType     `json:"post_type,omitempty"`    // Zabbix 4.0+
+	Posts           HttpFields   `json:"-"`                      // form fields for PostForm
+	RawPost         string       `json:"-"`                      // request body for PostRaw
+	Variables       HttpFields   `json:"variables,omitempty"`
+	Headers         HttpFields   `json:"headers,omitempty"`
+}
+
+// Sends RawPost or Posts as "posts" depending on PostType.
+func (s HttpStep) MarshalJSON() ([]byte, error) {
+	type httpStep HttpStep
+	v := struct {
+		httpStep
+		Posts interface{} `json:"posts,omitempty"`
+	}{httpStep: httpStep(s)}
+	if s.PostType == PostRaw && s.RawPost != "" {
+		v.Posts = s.RawPost
+	} else if s.PostType == PostForm && len(s.Posts) > 0 {
+		v.Posts = s.Posts
+	}
+	return json.Marshal(v)
+}
+
+// Decodes "posts" to RawPost if it is a string, or to Posts otherwise.
+func (s *HttpStep) UnmarshalJSON(b []byte) error {
+	type httpStep HttpStep
+	var v struct {
+		httpStep
+		Posts json.RawMessage `json:"posts"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*s = HttpStep(v.httpStep)
+	if len(v.Posts) > 0 && v.Posts[0] == '"' {
+		return json.Unmarshal(v.Posts, &s.RawPost)
+	}
+	if len(v.Posts) > 0 && v.Posts[0] == '[' {
+		return json.Unmarshal(v.Posts, &s.Posts)
+	}
+	return nil
+}
+
+type HttpSteps []HttpStep
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/httptest/object
+type HttpTest struct {
+	HttpTestId     string             `json:"httptestid,omitempty"`
+	HostId         string             `json:"hostid"` // host or template
+	Name           string             `json:"name"`
+	Delay          string             `json:"delay,omitempty"` // default "1m"
+	Retries        int                `json:"retries,omitempty"`
+	Agent          string             `json:"agent,omitempty"` // User-Agent header
+	HttpProxy      string             `json:"http_proxy,omitempty"`
+	Status         HttpTestStatus     `json:"status,omitempty"`
+	Authentication HttpAuthentication `json:"authentication,omitempty"`
+	HttpUser       string             `json:"http_user,omitempty"`
+	HttpPassword   string             `json:"http_password,omitempty"`
+	VerifyPeer     int                `json:"verify_peer,omitempty"`
+	VerifyHost     int                `json:"verify_host,omitempty"`
+	SSLCertFile    string             `json:"ssl_cert_file,omitempty"`
+	SSLKeyFile     string             `json:"ssl_key_file,omitempty"`
+	SSLKeyPassword string             `json:"ssl_key_password,omitempty"`
+	Variables      HttpFields         `json:"variables,omitempty"`
+	Headers        HttpFields         `json:"headers,omitempty"`
+	TemplateId     string             `json:"templateid,omitempty" zabbix:"readonly"`
+
+	Steps HttpSteps `json:"steps,omitempty"` // use "selectSteps" to get them
+	Tags  Tags      `json:"tags,omitempty"`  // Zabbix 5.4+, use "selectTags" to get them
+}
+
+type HttpTests []HttpTest
+
+func (t *HttpTest) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "httptest", IdField: "httptestid"}
+}
+func (t *HttpTest) ObjectId() string      { return t.HttpTestId }
+func (t *HttpTest) SetObjectId(id string) { t.HttpTestId = id }
+
+// Sends variables and headers as "name=value" lines and form fields as URL-encoded string before Zabbix 4.0.
+func (t *HttpTest) prepare(v *VersionInfo, m map[string]interface{}) {
+	if v.Supports(FeatureHttpFields) {
+		return
+	}
+	if len(t.Variables) > 0 {
+		m["variables"] = t.Variables.lines()
+	}
+	if len(t.Headers) > 0 {
+		m["headers"] = t.Headers.lines()
+	}
+	steps, _ := m["steps"].([]interface{})
+	for i, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok || i >= len(t.Steps) {
+			continue
+		}
+		delete(step, "query_fields")
+		delete(step, "post_type")
+		if len(t.Steps[i].Variables) > 0 {
+			step["variables"] = t.Steps[i].Variables.lines()
+		}
+		if len(t.Steps[i].Headers) > 0 {
+			step["headers"] = t.Steps[i].Headers.lines()
+		}
+		if _, form := step["posts"].([]interface{}); form {
+			pairs := make([]string, len(t.Steps[i].Posts))
+			for j, f := range t.Steps[i].Posts {
+				pairs[j] = url.QueryEscape(f.Name) + "=" + url.QueryEscape(f.Value)
+			}
+			step["posts"] = strings.Join(pairs, "&")
+		}
+	}
+}
+
+// Wrapper for httptest.get: https://www.zabbix.com/documentation/current/manual/api/reference/httptest/get
+func (api *API) HttpTestsGet(params Params) (res HttpTests, err error) {
+	return GetObjects[HttpTest](api, params)
+}
+
+// Gets web scenario with steps by Id only if there is exactly 1 matching web scenario.
+func (api *API) HttpTestGetById(id string) (res *HttpTest, err error) {
+	return GetObject[HttpTest](api, Params{"httptestids": id, "selectSteps": "extend"})
+}
+
+// Wrapper for httptest.create: https://www.zabbix.com/documentation/current/manual/api/reference/httptest/create
+func (api *API) HttpTestsCreate(tests HttpTests) (err error) {
+	return CreateObjects[HttpTest](api, tests)
+}
+
+// Wrapper for httptest.update: https://www.zabbix.com/documentation/current/manual/api/reference/httptest/update
+// Non-empty Steps replace existing ones, steps without HttpStepId are created.
+func (api *API) HttpTestsUpdate(tests HttpTests, fields ...string) (err error) {
+	return UpdateObjects[HttpTest](api, tests, fields...)
+}
+
+// Wrapper for httptest.delete: https://www.zabbix.com/documentation/current/manual/api/reference/httptest/delete
+// Cleans HttpTestId in all tests elements if call succeed.
+func (api *API) HttpTestsDelete(tests HttpTests) (err error) {
+	return DeleteObjects[HttpTest](api, tests)
+}
+
+// Wrapper for httptest.delete: https://www.zabbix.com/documentation/current/manual/api/reference/httptest/delete
+func (api *API) HttpTestsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[HttpTest](api, ids)
+}
+
+// Items created by server for web scenario, nil if not found.
+type HttpTestItems struct {
+	DownloadSpeed *Item                     // web.test.in[scenario,,bps]
+	FailedStep    *Item                     // web.test.fail[scenario], number of failed step or 0
+	LastError     *Item                     // web.test.error[scenario]
+	Steps         map[string]*HttpStepItems // by step name
+}
+
+// Items created by server for web scenario step, nil if not found.
+type HttpStepItems struct {
+	DownloadSpeed *Item // web.test.in[scenario,step,bps]
+	ResponseTime  *Item // web.test.time[scenario,step,resp]
+	ResponseCode  *Item // web.test.rspcode[scenario,step]
+}
+
+// Gets items of web scenario and its steps by web scenario Id.
+func (api *API) HttpTestItemsGet(id string) (res *HttpTestItems, err error) {
+	test, err := api.HttpTestGetById(id)
+	if err != nil {
+		return
+	}
+
+	name := itemKeyParam(test.Name)
+	res = &HttpTestItems{Steps: make(map[string]*HttpStepItems, len(test.Steps))}
+	targets := map[string]**Item{
+		"web.test.in[" + name + ",,bps]": &res.DownloadSpeed,
+		"web.test.fail[" + name + "]":    &res.FailedStep,
+		"web.test.error[" + name + "]":   &res.LastError,
+	}
+	for _, s := range test.Steps {
+		step := new(HttpStepItems)
+		res.Steps[s.Name] = step
+		params := name + "," + itemKeyParam(s.Name)
+		targets["web.test.in["+params+",bps]"] = &step.DownloadSpeed
+		targets["web.test.time["+params+",resp]"] = &step.ResponseTime
+		targets["web.test.rspcode["+params+"]"] = &step.ResponseCode
+	}
+
+	keys := make([]string, 0, len(targets))
+	for key := range targets {
+		keys = append(keys, key)
+	}
+	items, err := api.ItemsGet(Params{"hostids": test.HostId, "webitems": true, "filter": Params{"key_": keys}})
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if target, ok := targets[items[i].Key]; ok {
+			*target = &items[i]
+		}
+	}
+	return
+}
+
+// Returns item key parameter, quoted if needed.
+func itemKeyParam(s string) string {
+	if !strings.ContainsAny(s, ",]") && !strings.HasPrefix(s, `"`) && !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, " ") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,157 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+var noRedirects = 0
+
+var httpTest = HttpTest{
+	HostId:    "10",
+	Name:      "Shop",
+	Variables: HttpFields{{Name: "{user}", Value: "test"}},
+	Steps: HttpSteps{
+		{Name: "Login", No: 1, URL: "https://shop.example.com/login", StatusCodes: "200",
+			Posts: HttpFields{{Name: "user", Value: "{user}"}, {Name: "note", Value: "a b"}}},
+		{Name: "API", No: 2, URL: "https://shop.example.com/api", PostType: PostRaw, RawPost: `{"ping":1}`, FollowRedirects: &noRedirects,
+			QueryFields: HttpFields{{Name: "v", Value: "2"}}},
+	},
+}
+
+func TestHttpTestCreateVersions(t *testing.T) {
+	for version, expected := range map[string]string{
+		"3.4.0": `[{"hostid":"10","name":"Shop","steps":[` +
+			`{"name":"Login","no":1,"posts":"user=%7Buser%7D\u0026note=a+b","status_codes":"200","url":"https://shop.example.com/login"},` +
+			`{"follow_redirects":0,"name":"API","no":2,"posts":"{\"ping\":1}","url":"https://shop.example.com/api"}],` +
+			`"variables":"{user}=test"}]`,
+		"7.0.0": `[{"hostid":"10","name":"Shop","steps":[` +
+			`{"name":"Login","no":1,"posts":[{"name":"user","value":"{user}"},{"name":"note","value":"a b"}],"status_codes":"200","url":"https://shop.example.com/login"},` +
+			`{"follow_redirects":0,"name":"API","no":2,"post_type":1,"posts":"{\"ping\":1}","query_fields":[{"name":"v","value":"2"}],"url":"https://shop.example.com/api"}],` +
+			`"variables":[{"name":"{user}","value":"test"}]}]`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"httptest.create": func(raw json.RawMessage) interface{} {
+				if string(raw) != expected {
+					t.Errorf("%s: expected\n%s\ngot\n%s", version, expected, raw)
+				}
+				return map[string]interface{}{"httptestids": []string{"5"}}
+			},
+		})
+		tests := HttpTests{httpTest}
+		if err := api.HttpTestsCreate(tests); err != nil {
+			t.Fatal(err)
+		}
+		if tests[0].HttpTestId != "5" {
+			t.Errorf("unexpected Id %q", tests[0].HttpTestId)
+		}
+	}
+}
+
+func TestHttpTestDecode(t *testing.T) {
+	api := newFakeAPI(t, "3.4.0", map[string]fakeHandler{
+		"httptest.get": func(raw json.RawMessage) interface{} {
+			return json.RawMessage(`[{"httptestid":"5","hostid":"10","name":"Shop","status":"0","variables":"{a}=1\r\n{b}=x=y\r\n",
+				"steps":[{"httpstepid":"7","name":"API","no":"2","url":"https://shop.example.com/api","posts":"{\"ping\":1}","follow_redirects":"1"},
+				{"httpstepid":"8","name":"Login","no":"1","url":"https://shop.example.com/login","post_type":"0","posts":[{"name":"user","value":"x"}]}]}]`)
+		},
+	})
+	test, err := api.HttpTestGetById("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(test.Variables, HttpFields{{Name: "{a}", Value: "1"}, {Name: "{b}", Value: "x=y"}}) {
+		t.Errorf("unexpected variables %+v", test.Variables)
+	}
+	if len(test.Steps) != 2 || test.Steps[0].RawPost != `{"ping":1}` || test.Steps[0].No != 2 || test.Steps[0].FollowRedirects == nil || *test.Steps[0].FollowRedirects != 1 || test.Steps[1].FollowRedirects != nil ||
+		!reflect.DeepEqual(test.Steps[1].Posts, HttpFields{{Name: "user", Value: "x"}}) {
+		t.Errorf("unexpected steps %+v", test.Steps)
+	}
+}
+
+func TestHttpTestItemsGet(t *testing.T) {
+	expectedKeys := []string{
+		`web.test.error["Shop, main"]`,
+		`web.test.fail["Shop, main"]`,
+		`web.test.in["Shop, main",,bps]`,
+		`web.test.in["Shop, main",Login,bps]`,
+		`web.test.rspcode["Shop, main",Login]`,
+		`web.test.time["Shop, main",Login,resp]`,
+	}
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"httptest.get": func(raw json.RawMessage) interface{} {
+			return []map[string]interface{}{{
+				"httptestid": "5", "hostid": "10", "name": "Shop, main",
+				"steps": []map[string]string{{"httpstepid": "7", "name": "Login", "no": "1", "url": "https://shop.example.com"}},
+			}}
+		},
+		"item.get": func(raw json.RawMessage) interface{} {
+			var p struct {
+				HostIds  string `json:"hostids"`
+				WebItems bool   `json:"webitems"`
+				Filter   struct {
+					Key []string `json:"key_"`
+				} `json:"filter"`
+			}
+			if err := json.Unmarshal(raw, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			sort.Strings(p.Filter.Key)
+			if p.HostIds != "10" || !p.WebItems || !reflect.DeepEqual(p.Filter.Key, expectedKeys) {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return []map[string]string{
+				{"itemid": "100", "key_": `web.test.fail["Shop, main"]`},
+				{"itemid": "101", "key_": `web.test.time["Shop, main",Login,resp]`},
+			}
+		},
+	})
+
+	items, err := api.HttpTestItemsGet("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items.FailedStep == nil || items.FailedStep.ItemId != "100" || items.DownloadSpeed != nil {
+		t.Errorf("unexpected scenario items %+v", items)
+	}
+	step := items.Steps["Login"]
+	if step == nil || step.ResponseTime == nil || step.ResponseTime.ItemId != "101" || step.ResponseCode != nil {
+		t.Errorf("unexpected step items %+v", step)
+	}
+}
+
+func TestHttpTestItemKeys(t *testing.T) {
+	for _, c := range []struct{ scenario, step, key string }{
+		{"Shop", "Login", `web.test.time[Shop,Login,resp]`},
+		{"Shop, main", "Login", `web.test.time["Shop, main",Login,resp]`},
+		{"[prod] Shop", "Login", `web.test.time["[prod] Shop",Login,resp]`},
+		{"[prod Shop", "Login", `web.test.time["[prod Shop",Login,resp]`},
+		{"Shop", "Get [id]", `web.test.time[Shop,"Get [id]",resp]`},
+		{`"Shop"`, " Login", `web.test.time["\"Shop\""," Login",resp]`},
+	} {
+		api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+			"httptest.get": func(raw json.RawMessage) interface{} {
+				return []map[string]interface{}{{
+					"httptestid": "5", "hostid": "10", "name": c.scenario,
+					"steps": []map[string]string{{"httpstepid": "7", "name": c.step, "no": "1", "url": "https://shop.example.com"}},
+				}}
+			},
+			"item.get": func(raw json.RawMessage) interface{} {
+				return []map[string]string{{"itemid": "101", "key_": c.key}}
+			},
+		})
+
+		items, err := api.HttpTestItemsGet("5")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if step := items.Steps[c.step]; step == nil || step.ResponseTime == nil {
+			t.Errorf("%q %q: expected key %s", c.scenario, c.step, c.key)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package zabbix
+
+type (
+	ImageType int
+)
+
+const (
+	ImageIcon       ImageType = 1
+	ImageBackground ImageType = 2
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/image/object
+// Image data is returned only with "select_image" parameter.
+type Image struct {
+	ImageId   string    `json:"imageid,omitempty"`
+	Name      string    `json:"name"`
+	ImageType ImageType `json:"imagetype,omitempty"` // default is ImageIcon, can't be updated
+	Image     []byte    `json:"image,omitempty"`     // PNG, JPEG or GIF data, sent base64-encoded
+}
+
+type Images []Image
+
+func (i *Image) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "image", IdField: "imageid"} }
+func (i *Image) ObjectId() string       { return i.ImageId }
+func (i *Image) SetObjectId(id string)  { i.ImageId = id }
+
+// Wrapper for image.get: https://www.zabbix.com/documentation/current/manual/api/reference/image/get
+func (api *API) ImagesGet(params Params) (res Images, err error) {
+	return GetObjects[Image](api, params)
+}
+
+// Gets image without data by name only if there is exactly 1 matching image.
+func (api *API) ImageGetByName(name string) (res *Image, err error) {
+	return GetObject[Image](api, Params{"filter": map[string]string{"name": name}})
+}
+
+// Wrapper for image.create: https://www.zabbix.com/documentation/current/manual/api/reference/image/create
+func (api *API) ImagesCreate(images Images) (err error) {
+	return CreateObjects[Image](api, images)
+}
+
+// Wrapper for image.update: https://www.zabbix.com/documentation/current/manual/api/reference/image/update
+func (api *API) ImagesUpdate(images Images, fields ...string) (err error) {
+	return UpdateObjects[Image](api, images, fields...)
+}
+
+// Wrapper for image.delete: https://www.zabbix.com/documentation/current/manual/api/reference/image/delete
+// Cleans ImageId in all images elements if call succeed.
+func (api *API) ImagesDelete(images Images) (err error) {
+	return DeleteObjects[Image](api, images)
+}
+
+// Wrapper for image.delete: https://www.zabbix.com/documentation/current/manual/api/reference/image/delete
+func (api *API) ImagesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Image](api, ids)
+}
+
+// Icon of host with matching inventory field value.
+type IconMapping struct {
+	IconId        string `json:"iconid"`
+	Expression    string `json:"expression"`     // regular expression, or "@name" of global regular expression
+	InventoryLink int    `json:"inventory_link"` // number of host inventory field, e.g. 1 for "type"
+	SortOrder     int    `json:"sortorder"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/object
+type IconMap struct {
+	IconMapId     string        `json:"iconmapid,omitempty"`
+	Name          string        `json:"name"`
+	DefaultIconId string        `json:"default_iconid"`
+	Mappings      []IconMapping `json:"mappings,omitempty"` // use "selectMappings" to get them
+}
+
+type IconMaps []IconMap
+
+func (m *IconMap) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "iconmap", IdField: "iconmapid"} }
+func (m *IconMap) ObjectId() string       { return m.IconMapId }
+func (m *IconMap) SetObjectId(id string)  { m.IconMapId = id }
+
+// Wrapper for iconmap.get: https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/get
+func (api *API) IconMapsGet(params Params) (res IconMaps, err error) {
+	return GetObjects[IconMap](api, params)
+}
+
+// Gets icon map with mappings by Id only if there is exactly 1 matching icon map.
+func (api *API) IconMapGetById(id string) (res *IconMap, err error) {
+	return GetObject[IconMap](api, Params{"iconmapids": id, "selectMappings": "extend"})
+}
+
+// Wrapper for iconmap.create: https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/create
+func (api *API) IconMapsCreate(iconMaps IconMaps) (err error) {
+	return CreateObjects[IconMap](api, iconMaps)
+}
+
+// Wrapper for iconmap.update: https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/update
+// Non-empty Mappings replace existing ones.
+func (api *API) IconMapsUpdate(iconMaps IconMaps, fields ...string) (err error) {
+	return UpdateObjects[IconMap](api, iconMaps, fields...)
+}
+
+// Wrapper for iconmap.delete: https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/delete
+// Cleans IconMapId in all icon maps elements if call succeed.
+func (api *API) IconMapsDelete(iconMaps IconMaps) (err error) {
+	return DeleteObjects[IconMap](api, iconMaps)
+}
+
+// Wrapper for iconmap.delete: https://www.zabbix.com/documentation/current/manual/api/reference/iconmap/delete
+func (api *API) IconMapsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[IconMap](api, ids)
+}
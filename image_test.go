@@ -0,0 +1,58 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestImageCreateAndGet(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"image.create": func(raw json.RawMessage) interface{} {
+			if expected := `[{"image":"iVBORw0KGgo=","imagetype":1,"name":"Router"}]`; string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"imageids": []string{"151"}}
+		},
+		"image.get": func(raw json.RawMessage) interface{} {
+			return []map[string]string{{"imageid": "151", "name": "Router", "imagetype": "1", "image": "iVBORw0KGgo="}}
+		},
+	})
+
+	images := Images{{Name: "Router", ImageType: ImageIcon, Image: png}}
+	if err := api.ImagesCreate(images); err != nil {
+		t.Fatal(err)
+	}
+	if images[0].ImageId != "151" {
+		t.Errorf("unexpected Id %q", images[0].ImageId)
+	}
+
+	image, err := api.ImageGetByName("Router")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image.ImageId != "151" || image.ImageType != ImageIcon || string(image.Image) != string(png) {
+		t.Errorf("unexpected image %+v", image)
+	}
+}
+
+func TestIconMapCreate(t *testing.T) {
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"iconmap.create": func(raw json.RawMessage) interface{} {
+			expected := `[{"default_iconid":"2","mappings":[{"expression":"^router","iconid":"151","inventory_link":1,"sortorder":0}],"name":"Network"}]`
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"iconmapids": []string{"3"}}
+		},
+	})
+	iconMaps := IconMaps{{Name: "Network", DefaultIconId: "2", Mappings: []IconMapping{{IconId: "151", Expression: "^router", InventoryLink: 1}}}}
+	if err := api.IconMapsCreate(iconMaps); err != nil {
+		t.Fatal(err)
+	}
+	if iconMaps[0].IconMapId != "3" {
+		t.Errorf("unexpected Id %q", iconMaps[0].IconMapId)
+	}
+}
@@ -2,7 +2,6 @@ package zabbix
 
 import (
 	"fmt"
-	"github.com/canghai908/reflector"
 )
 
 type (
@@ -36,6 +35,7 @@ const (
 	Log       ValueType = 2
 	Unsigned  ValueType = 3
 	Text      ValueType = 4
+	Binary    ValueType = 5 // Zabbix 7.0+
 
 	Decimal     DataType = 0
 	Octal       DataType = 1
@@ -57,22 +57,48 @@ type Item struct {
 	Name        string    `json:"name"`
 	Type        ItemType  `json:"type"`
 	ValueType   ValueType `json:"value_type"`
-	DataType    DataType  `json:"data_type"`
-	Delta       DeltaType `json:"delta"`
+	DataType    DataType  `json:"data_type"` // before Zabbix 3.4
+	Delta       DeltaType `json:"delta"`     // before Zabbix 3.4
 	Description string    `json:"description"`
-	Error       string    `json:"error"`
+	Units       string    `json:"units,omitempty"`
+	Error       string    `json:"error" zabbix:"readonly"`
 	History     string    `json:"history,omitempty"`
 	Trends      string    `json:"trends,omitempty"`
-	Lastvalue   string    `json:"lastvalue"`
-	Lastclock   int64     `json:"lastclock"`
-	Prevvalue   string    `json:"prevvalue"`
+	Lastvalue   string    `json:"lastvalue" zabbix:"readonly"`
+	Lastclock   int64     `json:"lastclock" zabbix:"readonly"`
+	Prevvalue   string    `json:"prevvalue" zabbix:"readonly"`
+	TemplateId  string    `json:"templateid,omitempty" zabbix:"readonly"` // Id of parent template item, "0" if none
+	Flags       int       `json:"flags,omitempty" zabbix:"readonly"`      // 0 for plain items, 4 for discovered
 
-	// Fields below used only when creating applications
+	Tags Tags `json:"tags,omitempty"` // Zabbix 5.4+
+
+	// Fields below used only when creating applications.
+	// Zabbix 5.4+ has no applications, there values are sent as "Application" tags and should be names.
 	ApplicationIds []string `json:"applications,omitempty"`
 }
 
+// Name of tag replacing applications in Zabbix 5.4+.
+const ApplicationTag = "Application"
+
 type Items []Item
 
+func (i *Item) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "item", IdField: "itemid"} }
+func (i *Item) ObjectId() string       { return i.ItemId }
+func (i *Item) SetObjectId(id string)  { i.ItemId = id }
+
+// Translates applications to tags for Zabbix 5.4+.
+func (i *Item) prepare(v *VersionInfo, m map[string]interface{}) {
+	if v.Supports(FeatureApplications) || len(i.ApplicationIds) == 0 {
+		return
+	}
+	delete(m, "applications")
+	tags := append(Tags{}, i.Tags...)
+	for _, name := range i.ApplicationIds {
+		tags = append(tags, Tag{Tag: ApplicationTag, Value: name})
+	}
+	m["tags"] = tags
+}
+
 // Converts slice to map by key. Panics if there are duplicate keys.
 func (items Items) ByKey() (res map[string]Item) {
 	res = make(map[string]Item, len(items))
@@ -88,72 +114,62 @@ func (items Items) ByKey() (res map[string]Item) {
 
 // Wrapper for item.get https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/get
 func (api *API) ItemsGet(params Params) (res Items, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
-	}
-	response, err := api.CallWithError("item.get", params)
-	if err != nil {
-		return
-	}
-
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
-	return
+	return GetObjects[Item](api, params)
 }
 
-// Gets items by application Id.
+// Gets items by application Id. Returns ErrUnsupportedInVersion for Zabbix 5.4+,
+// which has no applications, use ItemsGetByApplicationName there.
 func (api *API) ItemsGetByApplicationId(id string) (res Items, err error) {
+	if err = api.require(FeatureApplications); err != nil {
+		return
+	}
 	return api.ItemsGet(Params{"applicationids": id})
 }
 
-// Wrapper for item.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/create
-func (api *API) ItemsCreate(items Items) (err error) {
-	response, err := api.CallWithError("item.create", items)
+// Gets items of host by application name.
+// Zabbix 5.4+ has no applications, there items with "Application" tag equal to name are returned.
+func (api *API) ItemsGetByApplicationName(hostId, name string) (res Items, err error) {
+	v, err := api.version()
 	if err != nil {
 		return
 	}
+	if !v.Supports(FeatureApplications) {
+		return api.ItemsGet(Params{
+			"hostids": hostId,
+			"tags":    []map[string]interface{}{{"tag": ApplicationTag, "value": name, "operator": 1}},
+		})
+	}
 
-	result := response.Result.(map[string]interface{})
-	itemids := result["itemids"].([]interface{})
-	for i, id := range itemids {
-		items[i].ItemId = id.(string)
+	apps, err := api.ApplicationsGet(Params{"hostids": hostId, "filter": map[string]string{"name": name}})
+	if err != nil || len(apps) == 0 {
+		return
 	}
-	return
+	ids := make([]string, len(apps))
+	for i, a := range apps {
+		ids[i] = a.ApplicationId
+	}
+	return api.ItemsGet(Params{"hostids": hostId, "applicationids": ids})
+}
+
+// Wrapper for item.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/create
+func (api *API) ItemsCreate(items Items) (err error) {
+	return CreateObjects[Item](api, items)
+}
+
+// Wrapper for item.update: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/update
+// Sends only ItemId, non-zero fields and fields listed by JSON name.
+func (api *API) ItemsUpdate(items Items, fields ...string) (err error) {
+	return UpdateObjects[Item](api, items, fields...)
 }
 
 // Wrapper for item.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/delete
 // Cleans ItemId in all items elements if call succeed.
 func (api *API) ItemsDelete(items Items) (err error) {
-	ids := make([]string, len(items))
-	for i, item := range items {
-		ids[i] = item.ItemId
-	}
-
-	err = api.ItemsDeleteByIds(ids)
-	if err == nil {
-		for i := range items {
-			items[i].ItemId = ""
-		}
-	}
-	return
+	return DeleteObjects[Item](api, items)
 }
 
 // Wrapper for item.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/delete
+// Some versions return object instead of array of Ids, it is handled too.
 func (api *API) ItemsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("item.delete", ids)
-	if err != nil {
-		return
-	}
-
-	result := response.Result.(map[string]interface{})
-	itemids1, ok := result["itemids"].([]interface{})
-	l := len(itemids1)
-	if !ok {
-		// some versions actually return map there
-		itemids2 := result["itemids"].(map[string]interface{})
-		l = len(itemids2)
-	}
-	if len(ids) != l {
-		err = &ExpectedMore{len(ids), l}
-	}
-	return
+	return DeleteObjectsByIds[Item](api, ids)
 }
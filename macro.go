@@ -0,0 +1,195 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+type (
+	MacroType int
+)
+
+const (
+	TextMacro   MacroType = 0
+	SecretMacro MacroType = 1 // Zabbix 5.0+, value is never returned by API
+	VaultMacro  MacroType = 2 // Zabbix 5.2+, value is a path to secret in vault
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/object
+// Host and template macro, HostId is Id of host or template.
+type UserMacro struct {
+	HostMacroId string    `json:"hostmacroid,omitempty"`
+	HostId      string    `json:"hostid,omitempty"`
+	Macro       string    `json:"macro"`
+	Value       string    `json:"value,omitempty"`
+	Type        MacroType `json:"type,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+type UserMacros []UserMacro
+
+func (m *UserMacro) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "usermacro", IdField: "hostmacroid"}
+}
+func (m *UserMacro) ObjectId() string      { return m.HostMacroId }
+func (m *UserMacro) SetObjectId(id string) { m.HostMacroId = id }
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/object#global-macro
+type GlobalMacro struct {
+	GlobalMacroId string    `json:"globalmacroid,omitempty"`
+	Macro         string    `json:"macro"`
+	Value         string    `json:"value,omitempty"`
+	Type          MacroType `json:"type,omitempty"`
+	Description   string    `json:"description,omitempty"`
+}
+
+type GlobalMacros []GlobalMacro
+
+func (m *GlobalMacro) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "usermacro", IdField: "globalmacroid"}
+}
+func (m *GlobalMacro) ObjectId() string      { return m.GlobalMacroId }
+func (m *GlobalMacro) SetObjectId(id string) { m.GlobalMacroId = id }
+
+// Wrapper for usermacro.get: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/get
+// Returns host and template macros.
+func (api *API) UserMacrosGet(params Params) (res UserMacros, err error) {
+	return GetObjects[UserMacro](api, params)
+}
+
+// Wrapper for usermacro.create: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/create
+func (api *API) UserMacrosCreate(macros UserMacros) (err error) {
+	return CreateObjects[UserMacro](api, macros)
+}
+
+// Wrapper for usermacro.update: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/update
+// Sends only HostMacroId, non-zero fields and fields listed by JSON name, e.g. "type" to make macro text.
+func (api *API) UserMacrosUpdate(macros UserMacros, fields ...string) (err error) {
+	return UpdateObjects[UserMacro](api, macros, fields...)
+}
+
+// Wrapper for usermacro.delete: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/delete
+// Cleans HostMacroId in all macros elements if call succeed.
+func (api *API) UserMacrosDelete(macros UserMacros) (err error) {
+	return DeleteObjects[UserMacro](api, macros)
+}
+
+// Wrapper for usermacro.delete: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/delete
+func (api *API) UserMacrosDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[UserMacro](api, ids)
+}
+
+// Wrapper for usermacro.get with "globalmacro" parameter: returns global macros.
+func (api *API) GlobalMacrosGet(params Params) (res GlobalMacros, err error) {
+	p := Params{"output": "extend"}
+	for k, v := range params {
+		p[k] = v
+	}
+	p["globalmacro"] = true
+	err = api.getObjects("usermacro.get", p, &res)
+	return
+}
+
+// Wrapper for usermacro.createglobal: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/createglobal
+func (api *API) GlobalMacrosCreate(macros GlobalMacros) (err error) {
+	params, err := prepareObjects[GlobalMacro](api, macros, false, nil)
+	if err != nil {
+		return
+	}
+	ids, err := api.callIds("usermacro.createglobal", "globalmacroids", params)
+	if err != nil {
+		return
+	}
+	if len(ids) != len(macros) {
+		return &ExpectedMore{len(macros), len(ids)}
+	}
+	for i, id := range ids {
+		macros[i].GlobalMacroId = id
+	}
+	return
+}
+
+// Wrapper for usermacro.updateglobal: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/updateglobal
+// Sends only GlobalMacroId, non-zero fields and fields listed by JSON name.
+func (api *API) GlobalMacrosUpdate(macros GlobalMacros, fields ...string) (err error) {
+	params, err := prepareObjects[GlobalMacro](api, macros, true, fields)
+	if err != nil {
+		return
+	}
+	ids, err := api.callIds("usermacro.updateglobal", "globalmacroids", params)
+	if err == nil && len(ids) != len(macros) {
+		err = &ExpectedMore{len(macros), len(ids)}
+	}
+	return
+}
+
+// Wrapper for usermacro.deleteglobal: https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/deleteglobal
+func (api *API) GlobalMacrosDeleteByIds(ids []string) (err error) {
+	return api.callMass("usermacro.deleteglobal", "globalmacroids", ids, ids)
+}
+
+var (
+	// ErrMacroNotFound is returned by MacroResolve if macro is not defined on host, its templates and globally.
+	ErrMacroNotFound = errors.New("macro not found")
+
+	// ErrMacroNotReadable is returned by MacroResolve if effective macro is secret or vault macro.
+	ErrMacroNotReadable = errors.New("macro value is not readable")
+)
+
+// Returns effective value of macro (like "{$SNMP_COMMUNITY}") for host the way Zabbix server resolves it:
+// host macro first, then macros of linked templates level by level (template with the lowest Id wins
+// on the same level), then global macro. Macro context is not taken into account.
+func (api *API) MacroResolve(hostId, macro string) (value string, err error) {
+	filter := Params{"macro": macro}
+	visited := map[string]bool{hostId: true}
+
+	for level := []string{hostId}; len(level) > 0; {
+		macros, err := api.UserMacrosGet(Params{"hostids": level, "filter": filter})
+		if err != nil {
+			return "", err
+		}
+		if len(macros) > 0 {
+			sortByNumericId(macros, func(m UserMacro) string { return m.HostId })
+			return macroValue(macros[0].Macro, macros[0].Value, macros[0].Type)
+		}
+
+		templates, err := api.TemplatesGet(Params{"hostids": level, "output": []string{"templateid"}})
+		if err != nil {
+			return "", err
+		}
+		level = nil
+		for _, t := range templates {
+			if !visited[t.TemplateId] {
+				visited[t.TemplateId] = true
+				level = append(level, t.TemplateId)
+			}
+		}
+	}
+
+	globals, err := api.GlobalMacrosGet(Params{"filter": filter})
+	if err != nil {
+		return
+	}
+	if len(globals) > 0 {
+		return macroValue(globals[0].Macro, globals[0].Value, globals[0].Type)
+	}
+	return "", fmt.Errorf("%s: %w", macro, ErrMacroNotFound)
+}
+
+func macroValue(macro, value string, t MacroType) (string, error) {
+	if t != TextMacro {
+		return "", fmt.Errorf("%s: %w", macro, ErrMacroNotReadable)
+	}
+	return value, nil
+}
+
+// Sorts slice by numeric Id returned by id.
+func sortByNumericId[T any](s []T, id func(T) string) {
+	sort.SliceStable(s, func(i, j int) bool {
+		a, _ := strconv.ParseUint(id(s[i]), 10, 64)
+		b, _ := strconv.ParseUint(id(s[j]), 10, 64)
+		return a < b
+	})
+}
@@ -0,0 +1,106 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func fakeMacroAPI(t *testing.T, macros []map[string]interface{}, links map[string][]string, globals []map[string]interface{}) *API {
+	return newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"usermacro.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			name := p["filter"].(map[string]interface{})["macro"]
+			res := []map[string]interface{}{}
+			if p["globalmacro"] == true {
+				for _, m := range globals {
+					if m["macro"] == name {
+						res = append(res, m)
+					}
+				}
+				return res
+			}
+			for _, id := range p["hostids"].([]interface{}) {
+				for _, m := range macros {
+					if m["hostid"] == id && m["macro"] == name {
+						res = append(res, m)
+					}
+				}
+			}
+			return res
+		},
+		"template.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			res := []map[string]interface{}{}
+			for _, id := range p["hostids"].([]interface{}) {
+				for _, tid := range links[id.(string)] {
+					res = append(res, map[string]interface{}{"templateid": tid})
+				}
+			}
+			return res
+		},
+	})
+}
+
+func TestMacroResolve(t *testing.T) {
+	macros := []map[string]interface{}{
+		{"hostmacroid": "1", "hostid": "10", "macro": "{$HOST}", "value": "host", "type": "0"},
+		{"hostmacroid": "2", "hostid": "30", "macro": "{$TPL}", "value": "tpl30", "type": "0"},
+		{"hostmacroid": "3", "hostid": "20", "macro": "{$TPL}", "value": "tpl20", "type": "0"},
+		{"hostmacroid": "4", "hostid": "40", "macro": "{$DEEP}", "value": "deep", "type": "0"},
+		{"hostmacroid": "5", "hostid": "10", "macro": "{$SECRET}", "value": "", "type": "1"},
+	}
+	links := map[string][]string{"10": {"30", "20"}, "20": {"40"}, "40": {"10"}}
+	globals := []map[string]interface{}{
+		{"globalmacroid": "7", "macro": "{$GLOBAL}", "value": "global", "type": "0"},
+		{"globalmacroid": "8", "macro": "{$HOST}", "value": "overridden", "type": "0"},
+	}
+	api := fakeMacroAPI(t, macros, links, globals)
+
+	for macro, expected := range map[string]string{
+		"{$HOST}":   "host",
+		"{$TPL}":    "tpl20",
+		"{$DEEP}":   "deep",
+		"{$GLOBAL}": "global",
+	} {
+		value, err := api.MacroResolve("10", macro)
+		if err != nil {
+			t.Errorf("%s: %s", macro, err)
+		} else if value != expected {
+			t.Errorf("%s: expected %q, got %q", macro, expected, value)
+		}
+	}
+
+	if _, err := api.MacroResolve("10", "{$SECRET}"); !errors.Is(err, ErrMacroNotReadable) {
+		t.Errorf("expected ErrMacroNotReadable, got %v", err)
+	}
+	if _, err := api.MacroResolve("10", "{$MISSING}"); !errors.Is(err, ErrMacroNotFound) {
+		t.Errorf("expected ErrMacroNotFound, got %v", err)
+	}
+}
+
+func TestGlobalMacrosCreate(t *testing.T) {
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"usermacro.createglobal": func(raw json.RawMessage) interface{} {
+			var p []map[string]interface{}
+			if err := json.Unmarshal(raw, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			if len(p) != 1 || p[0]["macro"] != "{$PASS}" || p[0]["type"] != float64(SecretMacro) {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return map[string]interface{}{"globalmacroids": []string{"12"}}
+		},
+	})
+
+	macros := GlobalMacros{{Macro: "{$PASS}", Value: "secret", Type: SecretMacro}}
+	if err := api.GlobalMacrosCreate(macros); err != nil {
+		t.Fatal(err)
+	}
+	if macros[0].GlobalMacroId != "12" {
+		t.Errorf("unexpected Id %q", macros[0].GlobalMacroId)
+	}
+}
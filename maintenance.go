@@ -0,0 +1,263 @@
+package zabbix
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	TimePeriodType int
+	DayOfWeek      int
+	MonthMask      int
+	WeekOfMonth    int
+	TagsEvalType   int
+	TagOperator    int
+)
+
+const (
+	TimePeriodOneTime TimePeriodType = 0
+	TimePeriodDaily   TimePeriodType = 2
+	TimePeriodWeekly  TimePeriodType = 3
+	TimePeriodMonthly TimePeriodType = 4
+)
+
+// Day of week mask, values may be combined: Monday | Friday.
+const (
+	Monday DayOfWeek = 1 << iota
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+	Sunday
+
+	Weekdays = Monday | Tuesday | Wednesday | Thursday | Friday
+	Weekend  = Saturday | Sunday
+)
+
+// Month mask, values may be combined: January | July.
+const (
+	January MonthMask = 1 << iota
+	February
+	March
+	April
+	May
+	June
+	July
+	August
+	September
+	October
+	November
+	December
+
+	AllMonths MonthMask = 1<<12 - 1
+)
+
+const (
+	FirstWeek  WeekOfMonth = 1
+	SecondWeek WeekOfMonth = 2
+	ThirdWeek  WeekOfMonth = 3
+	FourthWeek WeekOfMonth = 4
+	LastWeek   WeekOfMonth = 5
+)
+
+const (
+	TagsAndOr TagsEvalType = 0
+	TagsOr    TagsEvalType = 2
+)
+
+const (
+	TagEquals   TagOperator = 0
+	TagContains TagOperator = 2
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/object#time-period
+// Use OneTimePeriod, DailyPeriod, WeeklyPeriod, MonthlyPeriod and MonthlyWeekdayPeriod to create it.
+type TimePeriod struct {
+	TimePeriodType TimePeriodType `json:"timeperiod_type"`
+	Period         int64          `json:"period"`               // duration in seconds
+	StartDate      int64          `json:"start_date,omitempty"` // one-time period start, Unix time
+	StartTime      int64          `json:"start_time,omitempty"` // seconds since midnight for repeated periods
+	Every          int            `json:"every,omitempty"`      // every N days or weeks, or WeekOfMonth for monthly
+	DayOfWeek      DayOfWeek      `json:"dayofweek,omitempty"`  // weekly and monthly periods
+	Day            int            `json:"day,omitempty"`        // day of month for monthly periods
+	Month          MonthMask      `json:"month,omitempty"`      // monthly periods
+}
+
+type TimePeriods []TimePeriod
+
+// Returns one-time period starting at start.
+func OneTimePeriod(start time.Time, d time.Duration) TimePeriod {
+	return TimePeriod{TimePeriodType: TimePeriodOneTime, StartDate: start.Unix(), Period: int64(d / time.Second)}
+}
+
+// Returns period repeated every N days at start (time since midnight).
+func DailyPeriod(every int, start, d time.Duration) TimePeriod {
+	return TimePeriod{TimePeriodType: TimePeriodDaily, Every: every, StartTime: int64(start / time.Second), Period: int64(d / time.Second)}
+}
+
+// Returns period repeated every N weeks on given days at start (time since midnight).
+func WeeklyPeriod(every int, days DayOfWeek, start, d time.Duration) TimePeriod {
+	return TimePeriod{TimePeriodType: TimePeriodWeekly, Every: every, DayOfWeek: days, StartTime: int64(start / time.Second), Period: int64(d / time.Second)}
+}
+
+// Returns period repeated in given months on day of month at start (time since midnight).
+func MonthlyPeriod(months MonthMask, day int, start, d time.Duration) TimePeriod {
+	return TimePeriod{TimePeriodType: TimePeriodMonthly, Month: months, Day: day, StartTime: int64(start / time.Second), Period: int64(d / time.Second)}
+}
+
+// Returns period repeated in given months on given days of week of month at start (time since midnight),
+// e.g. on the last Sunday.
+func MonthlyWeekdayPeriod(months MonthMask, week WeekOfMonth, days DayOfWeek, start, d time.Duration) TimePeriod {
+	return TimePeriod{TimePeriodType: TimePeriodMonthly, Month: months, Every: int(week), DayOfWeek: days, StartTime: int64(start / time.Second), Period: int64(d / time.Second)}
+}
+
+// Problem tag of maintenance (Zabbix 4.0+), only problems with matching tags are suppressed.
+type ProblemTag struct {
+	Tag      string      `json:"tag"`
+	Operator TagOperator `json:"operator"`
+	Value    string      `json:"value,omitempty"`
+}
+
+type ProblemTags []ProblemTag
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/object
+type Maintenance struct {
+	MaintenanceId   string          `json:"maintenanceid,omitempty"`
+	Name            string          `json:"name"`
+	ActiveSince     int64           `json:"active_since"`
+	ActiveTill      int64           `json:"active_till"`
+	Description     string          `json:"description,omitempty"`
+	MaintenanceType MaintenanceType `json:"maintenance_type"`
+	TagsEvalType    TagsEvalType    `json:"tags_evaltype,omitempty"`
+	Tags            ProblemTags     `json:"tags,omitempty"` // only for MaintenanceWithData
+	TimePeriods     TimePeriods     `json:"timeperiods,omitempty"`
+
+	// Targets, sent as "hostids" and "groupids" before Zabbix 6.0.
+	// Use "selectHosts" and "selectGroups" to get them.
+	Hosts  HostIds      `json:"hosts,omitempty"`
+	Groups HostGroupIds `json:"groups,omitempty"`
+}
+
+type Maintenances []Maintenance
+
+// Decodes maintenance host groups from "groups" or "hostgroups" (Zabbix 6.2+).
+func (m *Maintenance) UnmarshalJSON(b []byte) error {
+	type maintenance Maintenance
+	var v struct {
+		maintenance
+		HostGroups HostGroupIds `json:"hostgroups"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*m = Maintenance(v.maintenance)
+	if v.HostGroups != nil {
+		m.Groups = v.HostGroups
+	}
+	return nil
+}
+
+func (m *Maintenance) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "maintenance", IdField: "maintenanceid"}
+}
+func (m *Maintenance) ObjectId() string      { return m.MaintenanceId }
+func (m *Maintenance) SetObjectId(id string) { m.MaintenanceId = id }
+
+// Translates hosts and groups to Ids before Zabbix 6.0.
+func (m *Maintenance) prepare(v *VersionInfo, p map[string]interface{}) {
+	if v.Supports(FeatureMaintenanceObjects) {
+		return
+	}
+	if _, present := p["hosts"]; present {
+		delete(p, "hosts")
+		ids := make([]string, len(m.Hosts))
+		for i, h := range m.Hosts {
+			ids[i] = h.HostId
+		}
+		p["hostids"] = ids
+	}
+	if _, present := p["groups"]; present {
+		delete(p, "groups")
+		ids := make([]string, len(m.Groups))
+		for i, g := range m.Groups {
+			ids[i] = g.GroupId
+		}
+		p["groupids"] = ids
+	}
+}
+
+// Wrapper for maintenance.get: https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/get
+func (api *API) MaintenancesGet(params Params) (res Maintenances, err error) {
+	return GetObjects[Maintenance](api, params)
+}
+
+// Gets maintenance by Id only if there is exactly 1 matching maintenance. Hosts, groups and time periods are selected.
+func (api *API) MaintenanceGetById(id string) (res *Maintenance, err error) {
+	return GetObject[Maintenance](api, Params{
+		"maintenanceids":    id,
+		"selectHosts":       []string{"hostid"},
+		"selectHostGroups":  []string{"groupid"},
+		"selectTimeperiods": "extend",
+		"selectTags":        "extend",
+	})
+}
+
+// Wrapper for maintenance.create: https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/create
+func (api *API) MaintenancesCreate(maintenances Maintenances) (err error) {
+	return CreateObjects[Maintenance](api, maintenances)
+}
+
+// Wrapper for maintenance.update: https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/update
+// Non-empty Hosts, Groups, Tags and TimePeriods replace existing ones.
+func (api *API) MaintenancesUpdate(maintenances Maintenances, fields ...string) (err error) {
+	return UpdateObjects[Maintenance](api, maintenances, fields...)
+}
+
+// Wrapper for maintenance.delete: https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/delete
+// Cleans MaintenanceId in all maintenances elements if call succeed.
+func (api *API) MaintenancesDelete(maintenances Maintenances) (err error) {
+	return DeleteObjects[Maintenance](api, maintenances)
+}
+
+// Wrapper for maintenance.delete: https://www.zabbix.com/documentation/current/manual/api/reference/maintenance/delete
+func (api *API) MaintenancesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Maintenance](api, ids)
+}
+
+// Creates one-time maintenance with data collection for hosts starting now and lasting d
+// (Zabbix rounds start down to a minute and requires at least 5 minutes).
+// Call release to delete maintenance before it ends, release may be called more than once.
+func (api *API) MaintenanceFor(hosts Hosts, d time.Duration) (m *Maintenance, release func() error, err error) {
+	now := time.Now()
+	m = &Maintenance{
+		Name:            fmt.Sprintf("Maintenance for %d host(s) %s", len(hosts), now.Format("2006-01-02 15:04:05.000000")),
+		ActiveSince:     now.Unix(),
+		ActiveTill:      now.Add(d).Unix(),
+		MaintenanceType: MaintenanceWithData,
+		TimePeriods:     TimePeriods{OneTimePeriod(now, d)},
+		Hosts:           make(HostIds, len(hosts)),
+	}
+	for i, h := range hosts {
+		m.Hosts[i].HostId = h.HostId
+	}
+
+	maintenances := Maintenances{*m}
+	if err = api.MaintenancesCreate(maintenances); err != nil {
+		return nil, nil, err
+	}
+	m.MaintenanceId = maintenances[0].MaintenanceId
+
+	release = func() error {
+		if m.MaintenanceId == "" {
+			return nil
+		}
+		err := api.MaintenancesDeleteByIds([]string{m.MaintenanceId})
+		if err == nil {
+			m.MaintenanceId = ""
+		}
+		return err
+	}
+	return m, release, nil
+}
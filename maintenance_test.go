@@ -0,0 +1,132 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestTimePeriods(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	for _, c := range []struct {
+		p        TimePeriod
+		expected string
+	}{
+		{OneTimePeriod(start, time.Hour), `{"timeperiod_type":0,"period":3600,"start_date":1700000000}`},
+		{DailyPeriod(2, 3*time.Hour, 30*time.Minute), `{"timeperiod_type":2,"period":1800,"start_time":10800,"every":2}`},
+		{WeeklyPeriod(1, Weekend, 0, time.Hour), `{"timeperiod_type":3,"period":3600,"every":1,"dayofweek":96}`},
+		{MonthlyPeriod(January|July, 15, time.Hour, time.Hour), `{"timeperiod_type":4,"period":3600,"start_time":3600,"day":15,"month":65}`},
+		{MonthlyWeekdayPeriod(AllMonths, LastWeek, Sunday, 0, time.Hour), `{"timeperiod_type":4,"period":3600,"every":5,"dayofweek":64,"month":4095}`},
+	} {
+		b, err := json.Marshal(c.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, b)
+		}
+	}
+}
+
+func TestMaintenanceCreateVersions(t *testing.T) {
+	for version, expected := range map[string][]string{
+		"5.0.0": {"groupids", "hostids"},
+		"6.4.0": {"groups", "hosts"},
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"maintenance.create": func(raw json.RawMessage) interface{} {
+				var p []map[string]interface{}
+				if err := json.Unmarshal(raw, &p); err != nil {
+					t.Error(err)
+					return nil
+				}
+				for _, key := range expected {
+					if _, present := p[0][key]; !present {
+						t.Errorf("%s: no %q in %s", version, key, raw)
+					}
+				}
+				return map[string]interface{}{"maintenanceids": []string{"3"}}
+			},
+		})
+
+		m := Maintenances{{
+			Name:        "deploy",
+			TimePeriods: TimePeriods{OneTimePeriod(time.Now(), time.Hour)},
+			Hosts:       HostIds{{HostId: "10"}},
+			Groups:      HostGroupIds{{GroupId: "2"}},
+		}}
+		if err := api.MaintenancesCreate(m); err != nil {
+			t.Fatal(err)
+		}
+		if m[0].MaintenanceId != "3" {
+			t.Errorf("unexpected Id %q", m[0].MaintenanceId)
+		}
+	}
+}
+
+func TestMaintenanceDecode(t *testing.T) {
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"maintenance.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if _, present := p["selectHostGroups"]; !present {
+				t.Errorf("expected selectHostGroups in %s", raw)
+			}
+			return []map[string]interface{}{{
+				"maintenanceid": "3", "name": "deploy", "active_since": "1700000000", "active_till": "1700003600",
+				"maintenance_type": "0", "tags_evaltype": "2",
+				"hosts":       []map[string]string{{"hostid": "10"}},
+				"hostgroups":  []map[string]string{{"groupid": "2"}},
+				"timeperiods": []map[string]string{{"timeperiod_type": "0", "period": "3600", "start_date": "1700000000"}},
+				"tags":        []map[string]string{{"tag": "service", "operator": "2", "value": "web"}},
+			}}
+		},
+	})
+
+	m, err := api.MaintenanceGetById("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Maintenance{
+		MaintenanceId: "3", Name: "deploy", ActiveSince: 1700000000, ActiveTill: 1700003600,
+		TagsEvalType: TagsOr,
+		Tags:         ProblemTags{{Tag: "service", Operator: TagContains, Value: "web"}},
+		TimePeriods:  TimePeriods{OneTimePeriod(time.Unix(1700000000, 0), time.Hour)},
+		Hosts:        HostIds{{HostId: "10"}},
+		Groups:       HostGroupIds{{GroupId: "2"}},
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("expected %+v, got %+v", expected, m)
+	}
+}
+
+func TestMaintenanceFor(t *testing.T) {
+	var deleted int
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"maintenance.create": func(raw json.RawMessage) interface{} {
+			return map[string]interface{}{"maintenanceids": []string{"5"}}
+		},
+		"maintenance.delete": func(raw json.RawMessage) interface{} {
+			deleted++
+			return map[string]interface{}{"maintenanceids": []string{"5"}}
+		},
+	})
+
+	m, release, err := api.MaintenanceFor(Hosts{{HostId: "10"}, {HostId: "11"}}, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MaintenanceId != "5" || len(m.Hosts) != 2 || m.ActiveTill-m.ActiveSince != 600 {
+		t.Errorf("unexpected maintenance %+v", m)
+	}
+	for i := 0; i < 2; i++ {
+		if err = release(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 delete, got %d", deleted)
+	}
+}
@@ -0,0 +1,245 @@
+package zabbix
+
+type (
+	MapElementType int
+	MapLabelType   int
+	LabelLocation  int
+	LinkDrawType   int
+	MapShapeType   int
+)
+
+const (
+	MapElementHost      MapElementType = 0
+	MapElementMap       MapElementType = 1
+	MapElementTrigger   MapElementType = 2
+	MapElementHostGroup MapElementType = 3
+	MapElementImage     MapElementType = 4
+)
+
+const (
+	MapLabelText    MapLabelType = 0
+	MapLabelIP      MapLabelType = 1
+	MapLabelName    MapLabelType = 2
+	MapLabelStatus  MapLabelType = 3
+	MapLabelNothing MapLabelType = 4
+	MapLabelCustom  MapLabelType = 5 // label types of element types only
+)
+
+const (
+	LabelDefault LabelLocation = -1 // element only, location of map
+	LabelBottom  LabelLocation = 0
+	LabelLeft    LabelLocation = 1
+	LabelRight   LabelLocation = 2
+	LabelTop     LabelLocation = 3
+)
+
+const (
+	LinkLine   LinkDrawType = 0
+	LinkBold   LinkDrawType = 2
+	LinkDotted LinkDrawType = 3
+	LinkDashed LinkDrawType = 4
+)
+
+const (
+	MapShapeRectangle MapShapeType = 0
+	MapShapeEllipse   MapShapeType = 1
+)
+
+// Object displayed by map element, only field matching element type is set.
+// Trigger elements may have multiple triggers, other elements have single object.
+type MapElementObject struct {
+	HostId    string `json:"hostid,omitempty"`
+	GroupId   string `json:"groupid,omitempty"`
+	TriggerId string `json:"triggerid,omitempty"`
+	SysmapId  string `json:"sysmapid,omitempty"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/map/object#map-element
+// SelementId is set by caller on create to refer element in links.
+type MapElement struct {
+	SelementId        string             `json:"selementid,omitempty"`
+	ElementType       MapElementType     `json:"elementtype"`
+	Elements          []MapElementObject `json:"elements,omitempty"` // not used for MapElementImage
+	IconIdOff         string             `json:"iconid_off"`         // Image Id, required
+	IconIdOn          string             `json:"iconid_on,omitempty"`
+	IconIdDisabled    string             `json:"iconid_disabled,omitempty"`
+	IconIdMaintenance string             `json:"iconid_maintenance,omitempty"`
+	UseIconMap        int                `json:"use_iconmap,omitempty"` // 1 to use IconMap of map for host elements
+	Label             string             `json:"label,omitempty"`
+	LabelLocation     *LabelLocation     `json:"label_location,omitempty"` // nil for default (LabelDefault)
+	X                 int                `json:"x"`
+	Y                 int                `json:"y"`
+	Width             int                `json:"width,omitempty"`  // host group elements shown as area
+	Height            int                `json:"height,omitempty"` // host group elements shown as area
+}
+
+type MapElements []MapElement
+
+// Decodes "elementid" of Zabbix before 4.0 into Elements.
+func (e *MapElement) UnmarshalJSON(b []byte) error {
+	type element MapElement
+	var v struct {
+		element
+		ElementId string `json:"elementid"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*e = MapElement(v.element)
+	if v.ElementId != "" && v.ElementId != "0" && e.Elements == nil {
+		var obj MapElementObject
+		switch e.ElementType {
+		case MapElementHost:
+			obj.HostId = v.ElementId
+		case MapElementMap:
+			obj.SysmapId = v.ElementId
+		case MapElementTrigger:
+			obj.TriggerId = v.ElementId
+		case MapElementHostGroup:
+			obj.GroupId = v.ElementId
+		default:
+			return nil
+		}
+		e.Elements = []MapElementObject{obj}
+	}
+	return nil
+}
+
+// Trigger which changes link style when in problem state.
+type MapLinkTrigger struct {
+	TriggerId string       `json:"triggerid"`
+	DrawType  LinkDrawType `json:"drawtype,omitempty"`
+	Color     string       `json:"color"` // hex RGB, e.g. "DD0000"
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/map/object#map-link
+type MapLink struct {
+	LinkId       string           `json:"linkid,omitempty" zabbix:"readonly"`
+	SelementId1  string           `json:"selementid1"`
+	SelementId2  string           `json:"selementid2"`
+	DrawType     LinkDrawType     `json:"drawtype,omitempty"`
+	Color        string           `json:"color,omitempty"` // hex RGB, default is "000000"
+	Label        string           `json:"label,omitempty"`
+	LinkTriggers []MapLinkTrigger `json:"linktriggers,omitempty"`
+}
+
+type MapLinks []MapLink
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/map/object#map-shape (Zabbix 3.4+)
+type MapShape struct {
+	ShapeId         string       `json:"sysmap_shapeid,omitempty" zabbix:"readonly"`
+	Type            MapShapeType `json:"type"`
+	X               int          `json:"x"`
+	Y               int          `json:"y"`
+	Width           int          `json:"width,omitempty"`
+	Height          int          `json:"height,omitempty"`
+	Text            string       `json:"text,omitempty"`
+	FontSize        int          `json:"font_size,omitempty"`
+	FontColor       string       `json:"font_color,omitempty"`
+	BorderWidth     int          `json:"border_width,omitempty"`
+	BorderColor     string       `json:"border_color,omitempty"`
+	BackgroundColor string       `json:"background_color,omitempty"` // empty for transparent
+	ZIndex          int          `json:"zindex,omitempty"`
+}
+
+type MapShapes []MapShape
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/map/object
+type Map struct {
+	SysmapId         string        `json:"sysmapid,omitempty"`
+	Name             string        `json:"name"`
+	Width            int           `json:"width"`
+	Height           int           `json:"height"`
+	BackgroundId     string        `json:"backgroundid,omitempty"` // Image Id of background
+	IconMapId        string        `json:"iconmapid,omitempty"`
+	LabelType        *MapLabelType `json:"label_type,omitempty"` // nil for default (MapLabelName)
+	LabelLocation    LabelLocation `json:"label_location"`
+	LabelFormat      int           `json:"label_format,omitempty"`         // 1 to use label types of element types below
+	LabelTypeHost    *MapLabelType `json:"label_type_host,omitempty"`      // nil for default (MapLabelName)
+	LabelTypeHostGrp *MapLabelType `json:"label_type_hostgroup,omitempty"` // nil for default (MapLabelName)
+	LabelTypeTrigger *MapLabelType `json:"label_type_trigger,omitempty"`   // nil for default (MapLabelName)
+	LabelTypeMap     *MapLabelType `json:"label_type_map,omitempty"`       // nil for default (MapLabelName)
+	LabelTypeImage   *MapLabelType `json:"label_type_image,omitempty"`     // nil for default (MapLabelName)
+	Highlight        *int          `json:"highlight,omitempty"`            // 0 - off, 1 - on, nil for default (1)
+	ExpandProblem    *int          `json:"expandproblem,omitempty"`        // 0 - off, 1 - on, nil for default (1)
+	MarkElements     int           `json:"markelements,omitempty"`
+	SeverityMin      int           `json:"severity_min,omitempty"`
+	GridSize         int           `json:"grid_size,omitempty"`
+	GridShow         *int          `json:"grid_show,omitempty"`  // 0 - off, 1 - on, nil for default (1)
+	GridAlign        *int          `json:"grid_align,omitempty"` // 0 - off, 1 - on, nil for default (1)
+	UserId           string        `json:"userid,omitempty"`     // owner, current user by default
+	Private          *int          `json:"private,omitempty"`    // 0 - public, 1 - private, nil for default (1)
+
+	// Use "selectSelements", "selectLinks" and "selectShapes" to get them.
+	Elements MapElements `json:"selements,omitempty"`
+	Links    MapLinks    `json:"links,omitempty"`
+	Shapes   MapShapes   `json:"shapes,omitempty"`
+}
+
+type Maps []Map
+
+func (m *Map) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "map", IdField: "sysmapid"} }
+func (m *Map) ObjectId() string       { return m.SysmapId }
+func (m *Map) SetObjectId(id string)  { m.SysmapId = id }
+
+var mapElementIdFields = []string{"hostid", "sysmapid", "triggerid", "groupid"}
+
+// Sends first object of element as "elementid" before Zabbix 4.0.
+func (m *Map) prepare(v *VersionInfo, params map[string]interface{}) {
+	if v.Supports(FeatureMapElements) {
+		return
+	}
+	selements, _ := params["selements"].([]interface{})
+	for _, s := range selements {
+		selement, _ := s.(map[string]interface{})
+		elements, _ := selement["elements"].([]interface{})
+		delete(selement, "elements")
+		if len(elements) == 0 {
+			continue
+		}
+		obj, _ := elements[0].(map[string]interface{})
+		for _, f := range mapElementIdFields {
+			if id, ok := obj[f]; ok {
+				selement["elementid"] = id
+			}
+		}
+	}
+}
+
+// Wrapper for map.get: https://www.zabbix.com/documentation/current/manual/api/reference/map/get
+func (api *API) MapsGet(params Params) (res Maps, err error) {
+	return GetObjects[Map](api, params)
+}
+
+// Gets map with elements, links and shapes by Id only if there is exactly 1 matching map.
+func (api *API) MapGetById(id string) (res *Map, err error) {
+	return GetObject[Map](api, Params{
+		"sysmapids":       id,
+		"selectSelements": "extend",
+		"selectLinks":     "extend",
+		"selectShapes":    "extend",
+	})
+}
+
+// Wrapper for map.create: https://www.zabbix.com/documentation/current/manual/api/reference/map/create
+func (api *API) MapsCreate(maps Maps) (err error) {
+	return CreateObjects[Map](api, maps)
+}
+
+// Wrapper for map.update: https://www.zabbix.com/documentation/current/manual/api/reference/map/update
+// Non-empty Elements, Links and Shapes replace existing ones.
+func (api *API) MapsUpdate(maps Maps, fields ...string) (err error) {
+	return UpdateObjects[Map](api, maps, fields...)
+}
+
+// Wrapper for map.delete: https://www.zabbix.com/documentation/current/manual/api/reference/map/delete
+// Cleans SysmapId in all maps elements if call succeed.
+func (api *API) MapsDelete(maps Maps) (err error) {
+	return DeleteObjects[Map](api, maps)
+}
+
+// Wrapper for map.delete: https://www.zabbix.com/documentation/current/manual/api/reference/map/delete
+func (api *API) MapsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Map](api, ids)
+}
@@ -0,0 +1,82 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+var (
+	labelTop  = LabelTop
+	labelText = MapLabelText
+)
+
+var topologyMap = Map{
+	Name: "Core", Width: 800, Height: 600, LabelFormat: 1, LabelTypeHost: &labelText,
+	Elements: MapElements{
+		{SelementId: "1", ElementType: MapElementHost, Elements: []MapElementObject{{HostId: "10"}}, IconIdOff: "151", X: 100, Y: 100},
+		{SelementId: "2", ElementType: MapElementHostGroup, Elements: []MapElementObject{{GroupId: "4"}}, IconIdOff: "152",
+			Label: "{HOST.GROUP}", LabelLocation: &labelTop, X: 400, Y: 100},
+	},
+	Links: MapLinks{{LinkId: "9", SelementId1: "1", SelementId2: "2", Color: "00CC00",
+		LinkTriggers: []MapLinkTrigger{{TriggerId: "300", DrawType: LinkBold, Color: "DD0000"}}}},
+	Shapes: MapShapes{{Type: MapShapeRectangle, Width: 800, Height: 20, Text: "{MAP.NAME}"}},
+}
+
+func TestMapCreateVersions(t *testing.T) {
+	links := `"links":[{"color":"00CC00","linktriggers":[{"color":"DD0000","drawtype":2,"triggerid":"300"}],"selementid1":"1","selementid2":"2"}]`
+	for version, expected := range map[string]string{
+		"3.2.0": `[{"height":600,"label_format":1,"label_location":0,"label_type_host":0,` + links + `,"name":"Core","selements":[` +
+			`{"elementid":"10","elementtype":0,"iconid_off":"151","selementid":"1","x":100,"y":100},` +
+			`{"elementid":"4","elementtype":3,"iconid_off":"152","label":"{HOST.GROUP}","label_location":3,"selementid":"2","x":400,"y":100}],"width":800}]`,
+		"7.0.0": `[{"height":600,"label_format":1,"label_location":0,"label_type_host":0,` + links + `,"name":"Core","selements":[` +
+			`{"elements":[{"hostid":"10"}],"elementtype":0,"iconid_off":"151","selementid":"1","x":100,"y":100},` +
+			`{"elements":[{"groupid":"4"}],"elementtype":3,"iconid_off":"152","label":"{HOST.GROUP}","label_location":3,"selementid":"2","x":400,"y":100}],` +
+			`"shapes":[{"height":20,"text":"{MAP.NAME}","type":0,"width":800,"x":0,"y":0}],"width":800}]`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"map.create": func(raw json.RawMessage) interface{} {
+				if string(raw) != expected {
+					t.Errorf("%s: expected\n%s\ngot\n%s", version, expected, raw)
+				}
+				return map[string]interface{}{"sysmapids": []string{"8"}}
+			},
+		})
+		maps := Maps{topologyMap}
+		if err := api.MapsCreate(maps); err != nil {
+			t.Fatal(err)
+		}
+		if maps[0].SysmapId != "8" {
+			t.Errorf("unexpected Id %q", maps[0].SysmapId)
+		}
+	}
+}
+
+func TestMapDecode(t *testing.T) {
+	api := newFakeAPI(t, "3.2.0", map[string]fakeHandler{
+		"map.get": func(raw json.RawMessage) interface{} {
+			if p := decodeParams(t, raw); p["selectShapes"] != nil {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return json.RawMessage(`[{"sysmapid":"8","name":"Core","width":"800","height":"600","label_type":"2","label_location":"0","private":"1",
+				"selements":[{"selementid":"1","elementid":"10","elementtype":"0","iconid_off":"151","label_location":"-1","x":"100","y":"100"},
+					{"selementid":"3","elementid":"0","elementtype":"4","iconid_off":"153","label_location":"-1","x":"0","y":"0"}],
+				"links":[{"linkid":"9","selementid1":"1","selementid2":"3","drawtype":"0","color":"000000","linktriggers":[]}]}]`)
+		},
+	})
+	m, err := api.MapGetById("8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.LabelType == nil || *m.LabelType != MapLabelName || m.Private == nil || *m.Private != 1 || len(m.Elements) != 2 || len(m.Links) != 1 || m.Links[0].LinkId != "9" {
+		t.Fatalf("unexpected map %+v", m)
+	}
+	if e := m.Elements[0]; !reflect.DeepEqual(e.Elements, []MapElementObject{{HostId: "10"}}) || e.LabelLocation == nil || *e.LabelLocation != LabelDefault || e.X != 100 {
+		t.Errorf("unexpected host element %+v", e)
+	}
+	if e := m.Elements[1]; e.Elements != nil || e.ElementType != MapElementImage {
+		t.Errorf("unexpected image element %+v", e)
+	}
+}
@@ -0,0 +1,201 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	MediaTypeKind   int
+	MediaTypeStatus int
+	SMTPSecurity    int
+	MessageFormat   int
+)
+
+const (
+	MediaTypeEmail   MediaTypeKind = 0
+	MediaTypeScript  MediaTypeKind = 1
+	MediaTypeSMS     MediaTypeKind = 2
+	MediaTypeWebhook MediaTypeKind = 4 // Zabbix 4.4+
+)
+
+const (
+	MediaTypeEnabled  MediaTypeStatus = 0
+	MediaTypeDisabled MediaTypeStatus = 1
+)
+
+const (
+	SMTPSecurityNone     SMTPSecurity = 0
+	SMTPSecuritySTARTTLS SMTPSecurity = 1
+	SMTPSecuritySSL      SMTPSecurity = 2
+)
+
+const (
+	MessageFormatText MessageFormat = 0
+	MessageFormatHTML MessageFormat = 1
+)
+
+// Parameter of webhook (Name and Value) or script (SortOrder and Value, Zabbix 6.0+) media type.
+type MediaTypeParameter struct {
+	Name      string `json:"name,omitempty"`
+	SortOrder int    `json:"sortorder,omitempty"`
+	Value     string `json:"value"`
+}
+
+// Sends sortorder for script parameters, which have no name, even if it is 0.
+func (p MediaTypeParameter) MarshalJSON() ([]byte, error) {
+	if p.Name != "" {
+		return json.Marshal(map[string]string{"name": p.Name, "value": p.Value})
+	}
+	return json.Marshal(map[string]interface{}{"sortorder": p.SortOrder, "value": p.Value})
+}
+
+type MediaTypeParameters []MediaTypeParameter
+
+// Default message of media type for event source and operation mode (Zabbix 5.0+).
+type MessageTemplate struct {
+	EventSource EventSource `json:"eventsource"`
+	Recovery    int         `json:"recovery"` // 0 - operations, 1 - recovery operations, 2 - update operations
+	Subject     string      `json:"subject,omitempty"`
+	Message     string      `json:"message,omitempty"`
+}
+
+type MessageTemplates []MessageTemplate
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/object
+type MediaType struct {
+	MediaTypeId     string          `json:"mediatypeid,omitempty"`
+	Type            MediaTypeKind   `json:"type"`
+	Name            string          `json:"name"` // "description" before Zabbix 4.4
+	Description     string          `json:"description,omitempty"`
+	Status          MediaTypeStatus `json:"status,omitempty"`
+	MaxSessions     int             `json:"maxsessions,omitempty"`
+	MaxAttempts     int             `json:"maxattempts,omitempty"`
+	AttemptInterval string          `json:"attempt_interval,omitempty"`
+
+	// Email
+	SMTPServer         string        `json:"smtp_server,omitempty"`
+	SMTPPort           int           `json:"smtp_port,omitempty"`
+	SMTPHelo           string        `json:"smtp_helo,omitempty"`
+	SMTPEmail          string        `json:"smtp_email,omitempty"`
+	SMTPSecurity       SMTPSecurity  `json:"smtp_security,omitempty"`
+	SMTPVerifyHost     int           `json:"smtp_verify_host,omitempty"`
+	SMTPVerifyPeer     int           `json:"smtp_verify_peer,omitempty"`
+	SMTPAuthentication int           `json:"smtp_authentication,omitempty"`
+	Username           string        `json:"username,omitempty"`
+	Passwd             string        `json:"passwd,omitempty"` // write only
+	ContentType        MessageFormat `json:"content_type,omitempty"`
+
+	// Script and SMS
+	ExecPath   string `json:"exec_path,omitempty"`
+	ExecParams string `json:"exec_params,omitempty"` // before Zabbix 6.0, use Parameters later
+	GSMModem   string `json:"gsm_modem,omitempty"`
+
+	// Webhook
+	Script        string `json:"script,omitempty"` // JavaScript body
+	Timeout       string `json:"timeout,omitempty"`
+	ProcessTags   int    `json:"process_tags,omitempty"`
+	ShowEventMenu int    `json:"show_event_menu,omitempty"`
+	EventMenuURL  string `json:"event_menu_url,omitempty"`
+	EventMenuName string `json:"event_menu_name,omitempty"`
+
+	Parameters       MediaTypeParameters `json:"parameters,omitempty"`        // webhook and script
+	MessageTemplates MessageTemplates    `json:"message_templates,omitempty"` // use "selectMessageTemplates" to get them
+}
+
+type MediaTypes []MediaType
+
+// Decodes media type across Zabbix versions: fills Name from "description" before Zabbix 4.4.
+func (m *MediaType) UnmarshalJSON(b []byte) error {
+	type mediaType MediaType
+	var v mediaType
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*m = MediaType(v)
+	if m.Name == "" {
+		m.Name, m.Description = m.Description, ""
+	}
+	return nil
+}
+
+func (m *MediaType) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "mediatype", IdField: "mediatypeid"}
+}
+func (m *MediaType) ObjectId() string      { return m.MediaTypeId }
+func (m *MediaType) SetObjectId(id string) { m.MediaTypeId = id }
+
+// Wrapper for mediatype.get: https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/get
+func (api *API) MediaTypesGet(params Params) (res MediaTypes, err error) {
+	return GetObjects[MediaType](api, params)
+}
+
+// Gets media type by Id only if there is exactly 1 matching media type.
+func (api *API) MediaTypeGetById(id string) (res *MediaType, err error) {
+	return GetObject[MediaType](api, Params{"mediatypeids": id})
+}
+
+// Wrapper for mediatype.create: https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/create
+func (api *API) MediaTypesCreate(mediaTypes MediaTypes) (err error) {
+	return CreateObjects[MediaType](api, mediaTypes)
+}
+
+// Wrapper for mediatype.update: https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/update
+// Non-empty Parameters and MessageTemplates replace existing ones.
+func (api *API) MediaTypesUpdate(mediaTypes MediaTypes, fields ...string) (err error) {
+	return UpdateObjects[MediaType](api, mediaTypes, fields...)
+}
+
+// Wrapper for mediatype.delete: https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/delete
+// Cleans MediaTypeId in all mediaTypes elements if call succeed.
+func (api *API) MediaTypesDelete(mediaTypes MediaTypes) (err error) {
+	return DeleteObjects[MediaType](api, mediaTypes)
+}
+
+// Wrapper for mediatype.delete: https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/delete
+func (api *API) MediaTypesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[MediaType](api, ids)
+}
+
+// Test message: SendTo, Subject and Message for email, SMS and script media types, Parameters for webhooks.
+type MediaTypeTest struct {
+	SendTo     string              `json:"sendto,omitempty"`
+	Subject    string              `json:"subject,omitempty"`
+	Message    string              `json:"message,omitempty"`
+	Parameters MediaTypeParameters `json:"parameters,omitempty"`
+}
+
+// Result of mediatype.test. Response is returned by webhooks only.
+type MediaTypeTestResult struct {
+	Response string `json:"response"`
+	Debug    []struct {
+		Ms      int    `json:"ms"`
+		Message string `json:"message"`
+	} `json:"debug"`
+}
+
+// Wrapper for mediatype.test (Zabbix 7.0+): https://www.zabbix.com/documentation/current/manual/api/reference/mediatype/test
+// Sends test message with media type, failed delivery is returned as API error.
+func (api *API) MediaTypeTest(id string, test MediaTypeTest) (res *MediaTypeTestResult, err error) {
+	if err = api.require(FeatureMediaTypeTest); err != nil {
+		return
+	}
+	params, err := createParams(&test)
+	if err != nil {
+		return
+	}
+	params["mediatypeid"] = id
+
+	var raw json.RawMessage
+	if err = api.callResult("mediatype.test", params, &raw); err != nil {
+		return
+	}
+	res = new(MediaTypeTestResult)
+	if len(raw) > 0 && raw[0] == '{' {
+		if err = unmarshalLenient(raw, res); err != nil {
+			return nil, newDecodeError("mediatype.test", raw, fmt.Errorf("unexpected result: %w", err))
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestMediaTypeCreateVersions(t *testing.T) {
+	for version, expected := range map[string]string{
+		"4.2.0": `[{"type":0,"description":"Email","smtp_server":"mail.example.com","smtp_port":25}]`,
+		"6.0.0": `[{"type":0,"name":"Email","description":"Ops mail","smtp_server":"mail.example.com","smtp_port":25}]`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"mediatype.create": func(raw json.RawMessage) interface{} {
+				var p, e interface{}
+				json.Unmarshal(raw, &p)
+				json.Unmarshal([]byte(expected), &e)
+				if !reflect.DeepEqual(p, e) {
+					t.Errorf("%s: expected %s, got %s", version, expected, raw)
+				}
+				return map[string]interface{}{"mediatypeids": []string{"1"}}
+			},
+		})
+
+		m := MediaTypes{{Type: MediaTypeEmail, Name: "Email", Description: "Ops mail", SMTPServer: "mail.example.com", SMTPPort: 25}}
+		if err := api.MediaTypesCreate(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMediaTypeParameters(t *testing.T) {
+	b, err := json.Marshal(MediaTypeParameters{{Name: "URL", Value: "{ALERT.SENDTO}"}, {Value: "{ALERT.SUBJECT}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"name":"URL","value":"{ALERT.SENDTO}"},{"sortorder":0,"value":"{ALERT.SUBJECT}"}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestMediaTypeDecode(t *testing.T) {
+	api := newFakeAPI(t, "4.2.0", map[string]fakeHandler{
+		"mediatype.get": func(raw json.RawMessage) interface{} {
+			return []map[string]string{{"mediatypeid": "1", "type": "2", "description": "SMS", "gsm_modem": "/dev/ttyS0"}}
+		},
+	})
+	m, err := api.MediaTypeGetById("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &MediaType{MediaTypeId: "1", Type: MediaTypeSMS, Name: "SMS", GSMModem: "/dev/ttyS0"}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("expected %+v, got %+v", expected, m)
+	}
+}
+
+func TestMediaTypeTest(t *testing.T) {
+	api := newFakeAPI(t, "6.4.0", nil)
+	if _, err := api.MediaTypeTest("1", MediaTypeTest{}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+
+	api = newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"mediatype.test": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["mediatypeid"] != "4" || len(p["parameters"].([]interface{})) != 1 {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return map[string]interface{}{"response": `{"ok":true}`, "debug": []map[string]interface{}{{"ms": "12", "message": "sent"}}}
+		},
+	})
+	res, err := api.MediaTypeTest("4", MediaTypeTest{Parameters: MediaTypeParameters{{Name: "URL", Value: "https://example.com"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Response != `{"ok":true}` || len(res.Debug) != 1 || res.Debug[0].Ms != 12 {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestAlertsGet(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"alert.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["time_from"] != float64(1700000000) || p["eventsource"] != float64(0) {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return []map[string]string{
+				{"alertid": "1", "eventid": "10", "clock": "1700000100", "status": "1", "sendto": "a@example.com"},
+				{"alertid": "2", "eventid": "11", "clock": "1700000200", "status": "2", "retries": "3", "error": "timeout"},
+			}
+		},
+	})
+
+	source := EventSourceTrigger
+	alerts, err := api.AlertsGet(AlertQuery{EventSource: &source, TimeFrom: time.Unix(1700000000, 0)}.Params())
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := alerts.Failed()
+	if len(alerts) != 2 || len(failed) != 1 || failed[0].Error != "timeout" || failed[0].Retries != 3 {
+		t.Errorf("unexpected alerts %+v", alerts)
+	}
+	if !alerts[0].Time().Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("unexpected time %s", alerts[0].Time())
+	}
+}
@@ -0,0 +1,357 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ObjectInfo describes how Zabbix object is accessed over API.
+type ObjectInfo struct {
+	Prefix   string  // API method prefix, e.g. "host" for host.get
+	IdField  string  // name of Id field, e.g. "hostid"
+	Requires Feature // feature required by all object methods, zero if none
+}
+
+// Returns name of Ids parameter and result key, e.g. "hostids".
+func (info ObjectInfo) IdsKey() string {
+	return info.IdField + "s"
+}
+
+// Object is implemented by pointers to Zabbix object types, e.g. *Host.
+// It allows generic functions GetObjects, GetObject, CreateObjects, UpdateObjects and DeleteObjects
+// to be used with those types (plain Get name is taken by Zabbix Get Protocol).
+type Object interface {
+	ObjectInfo() ObjectInfo
+	ObjectId() string
+	SetObjectId(id string)
+}
+
+// ObjectPointer is a constraint for generic functions: P is *T and implements Object.
+type ObjectPointer[T any] interface {
+	*T
+	Object
+}
+
+func objectInfo[T any, P ObjectPointer[T]]() ObjectInfo {
+	var zero T
+	return P(&zero).ObjectInfo()
+}
+
+// Generic wrapper for <prefix>.get. Sets "output" to "extend" if it is not present.
+// Parameters renamed between Zabbix versions are translated.
+func GetObjects[T any, P ObjectPointer[T]](api *API, params Params) (res []T, err error) {
+	info := objectInfo[T, P]()
+	if err = api.require(info.Requires); err != nil {
+		return
+	}
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	p := make(Params, len(params))
+	for k, value := range params {
+		p[k] = value
+	}
+	applyFieldRules(v, getParamRules, info.Prefix, p)
+
+	err = api.getObjects(info.Prefix+".get", p, &res)
+	return
+}
+
+// Generic wrapper for <prefix>.get returning object only if there is exactly 1 matching object.
+func GetObject[T any, P ObjectPointer[T]](api *API, params Params) (res *T, err error) {
+	objects, err := GetObjects[T, P](api, params)
+	if err != nil {
+		return
+	}
+
+	if len(objects) == 1 {
+		res = &objects[0]
+	} else {
+		e := ExpectedOneResult(len(objects))
+		err = &e
+	}
+	return
+}
+
+// Generic wrapper for <prefix>.create. Sets Ids of objects if call succeed.
+// Read-only fields are not sent, fields not supported by server version are translated or removed.
+func CreateObjects[T any, P ObjectPointer[T]](api *API, objects []T) (err error) {
+	info := objectInfo[T, P]()
+	params, err := prepareObjects[T, P](api, objects, false, nil)
+	if err != nil {
+		return
+	}
+	ids, err := api.callIds(info.Prefix+".create", info.IdsKey(), params)
+	if err != nil {
+		return
+	}
+	if len(ids) != len(objects) {
+		return &ExpectedMore{len(objects), len(ids)}
+	}
+	for i, id := range ids {
+		P(&objects[i]).SetObjectId(id)
+	}
+	return
+}
+
+// Generic wrapper for <prefix>.update. Objects must have Ids set.
+// Only Id, non-zero fields and fields listed by JSON name in fields are sent, so zero values
+// don't overwrite server state unless requested. Read-only fields are never sent,
+// fields not supported by server version are translated or removed.
+func UpdateObjects[T any, P ObjectPointer[T]](api *API, objects []T, fields ...string) (err error) {
+	info := objectInfo[T, P]()
+	params, err := prepareObjects[T, P](api, objects, true, fields)
+	if err != nil {
+		return
+	}
+	ids, err := api.callIds(info.Prefix+".update", info.IdsKey(), params)
+	if err == nil && len(ids) != len(objects) {
+		err = &ExpectedMore{len(objects), len(ids)}
+	}
+	return
+}
+
+// Generic wrapper for <prefix>.delete.
+func DeleteObjectsByIds[T any, P ObjectPointer[T]](api *API, ids []string) (err error) {
+	info := objectInfo[T, P]()
+	if err = api.require(info.Requires); err != nil {
+		return
+	}
+	deleted, err := api.callIds(info.Prefix+".delete", info.IdsKey(), ids)
+	if err == nil && len(deleted) != len(ids) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// Generic delete of objects: calls DeleteObjectsByIds with their Ids and cleans Ids if call succeed.
+func DeleteObjects[T any, P ObjectPointer[T]](api *API, objects []T) (err error) {
+	ids := make([]string, len(objects))
+	for i := range objects {
+		ids[i] = P(&objects[i]).ObjectId()
+	}
+
+	err = DeleteObjectsByIds[T, P](api, ids)
+	if err == nil {
+		for i := range objects {
+			P(&objects[i]).SetObjectId("")
+		}
+	}
+	return
+}
+
+// Calls mass method and checks that all objects with ids are returned in result[key].
+func (api *API) callMass(method, key string, ids []string, params interface{}) (err error) {
+	res, err := api.callIds(method, key, params)
+	if err == nil && len(res) != len(ids) {
+		err = &ExpectedMore{len(ids), len(res)}
+	}
+	return
+}
+
+// Calls get method and decodes array of objects in result to slice pointed by res.
+func (api *API) getObjects(method string, params interface{}, res interface{}) (err error) {
+	return api.callResult(method, params, res)
+}
+
+// Calls create, update, delete or mass method and returns Ids from result[key].
+// Some versions return object with Ids as values and positions as keys instead of array.
+func (api *API) callIds(method, key string, params interface{}) (ids []string, err error) {
+	var result map[string]json.RawMessage
+	err = api.callResult(method, params, &result)
+	if err != nil {
+		return
+	}
+
+	raw, ok := result[key]
+	if !ok {
+		return nil, newDecodeError(method, nil, fmt.Errorf("no %q in result", key))
+	}
+	if err = unmarshalLenient(raw, &ids); err != nil {
+		var byKey map[string]string
+		if e := unmarshalLenient(raw, &byKey); e != nil {
+			return nil, newDecodeError(method, raw, err)
+		}
+		// keys are positions of objects in request
+		ids = make([]string, len(byKey))
+		for k, id := range byKey {
+			i, e := strconv.Atoi(k)
+			if e != nil || i < 0 || i >= len(ids) || ids[i] != "" {
+				return nil, newDecodeError(method, raw, fmt.Errorf("bad position %q in %q", k, key))
+			}
+			ids[i] = id
+		}
+	}
+
+	for _, id := range ids {
+		if id == "" {
+			return nil, newDecodeError(method, raw, fmt.Errorf("empty Id in %q", key))
+		}
+	}
+	return ids, nil
+}
+
+// Returns create or update parameters of objects for server version.
+func prepareObjects[T any, P ObjectPointer[T]](api *API, objects []T, update bool, fields []string) (res []map[string]interface{}, err error) {
+	info := objectInfo[T, P]()
+	if err = api.require(info.Requires); err != nil {
+		return
+	}
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+
+	res = make([]map[string]interface{}, len(objects))
+	for i := range objects {
+		if update {
+			res[i], err = updateParams(&objects[i], info.IdField, fields)
+		} else {
+			res[i], err = createParams(&objects[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if p, ok := interface{}(P(&objects[i])).(objectPreparer); ok {
+			p.prepare(v, res[i])
+		}
+		applyFieldRules(v, objectFieldRules, info.Prefix, res[i])
+	}
+	return
+}
+
+// Returns create parameters of struct pointed by v: JSON encoding without fields tagged with `zabbix:"readonly"`,
+// including fields of nested objects.
+func createParams(v interface{}) (res map[string]interface{}, err error) {
+	if err = jsonParams(v, &res); err != nil {
+		return
+	}
+	stripReadonly(res, reflect.TypeOf(v))
+	return
+}
+
+// Encodes v to JSON and decodes it to res with numbers as json.Number.
+func jsonParams(v interface{}, res *map[string]interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(res)
+}
+
+// Removes fields tagged with `zabbix:"readonly"` from JSON value of type t decoded to interface{},
+// including fields of nested objects.
+func stripReadonly(value interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			readonly := readonlyFields(t)
+			fields := structFields(t)
+			for k, e := range v {
+				if readonly[k] {
+					delete(v, k)
+				} else if ft, ok := fields[k]; ok {
+					stripReadonly(e, ft)
+				}
+			}
+		case reflect.Map:
+			for _, e := range v {
+				stripReadonly(e, t.Elem())
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, e := range v {
+				stripReadonly(e, t.Elem())
+			}
+		}
+	}
+}
+
+var readonlyFieldsCache sync.Map // reflect.Type -> map[string]bool
+
+// Returns JSON names of fields tagged with `zabbix:"readonly"`, including fields of embedded structs.
+func readonlyFields(t reflect.Type) map[string]bool {
+	if fields, ok := readonlyFieldsCache.Load(t); ok {
+		return fields.(map[string]bool)
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			for name := range readonlyFields(sf.Type) {
+				fields[name] = true
+			}
+			continue
+		}
+		if sf.Tag.Get("zabbix") == "readonly" {
+			if name := strings.Split(sf.Tag.Get("json"), ",")[0]; name != "" {
+				fields[name] = true
+			}
+		}
+	}
+
+	readonlyFieldsCache.Store(t, fields)
+	return fields
+}
+
+// Returns update parameters of struct pointed by v: Id field, non-zero fields and explicitly listed fields.
+// Fields tagged with `zabbix:"readonly"` are skipped, including fields of nested objects.
+func updateParams(v interface{}, idField string, fields []string) (res map[string]interface{}, err error) {
+	explicit := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		explicit[f] = true
+	}
+	values := make(map[string]interface{})
+	addUpdateParams(values, reflect.ValueOf(v).Elem(), idField, explicit)
+
+	if err = jsonParams(values, &res); err != nil {
+		return
+	}
+	stripReadonly(res, reflect.TypeOf(v))
+	return
+}
+
+func addUpdateParams(res map[string]interface{}, s reflect.Value, idField string, explicit map[string]bool) {
+	t := s.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		f := s.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			addUpdateParams(res, f, idField, explicit)
+			continue
+		}
+		if sf.PkgPath != "" || sf.Tag.Get("zabbix") == "readonly" {
+			continue
+		}
+
+		name := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if name == idField || explicit[name] || !f.IsZero() {
+			res[name] = f.Interface()
+		}
+	}
+}
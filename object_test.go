@@ -0,0 +1,139 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestObjectsCreateAndGet(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"hostgroup.create": func(params json.RawMessage) interface{} {
+			return map[string]interface{}{"groupids": []string{"10", "11"}}
+		},
+		"hostgroup.get": func(params json.RawMessage) interface{} {
+			if p := decodeParams(t, params); p["output"] != "extend" {
+				t.Errorf("Unexpected output: %v", p["output"])
+			}
+			return []map[string]string{{"groupid": "10", "name": "a", "internal": "1"}}
+		},
+		"item.delete": func(params json.RawMessage) interface{} {
+			return map[string]interface{}{"itemids": map[string]string{"0": "1", "1": "2"}}
+		},
+	})
+
+	groups := HostGroups{{Name: "a"}, {Name: "b"}}
+	if err := api.HostGroupsCreate(groups); err != nil {
+		t.Fatal(err)
+	}
+	if groups[0].GroupId != "10" || groups[1].GroupId != "11" {
+		t.Errorf("Ids are not set: %#v", groups)
+	}
+
+	group, err := GetObject[HostGroup](api, Params{"groupids": "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *group != (HostGroup{GroupId: "10", Name: "a", Internal: Internal}) {
+		t.Errorf("Unexpected group: %#v", group)
+	}
+
+	if err = api.ItemsDeleteByIds([]string{"1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestObjectsCreateIdsObject(t *testing.T) {
+	api := newFakeAPI(t, "3.0.0", map[string]fakeHandler{
+		"hostgroup.create": func(params json.RawMessage) interface{} {
+			ids := make(map[string]string)
+			for i := 11; i >= 0; i-- {
+				ids[strconv.Itoa(i)] = strconv.Itoa(100 + i)
+			}
+			return map[string]interface{}{"groupids": ids}
+		},
+		"item.create": func(params json.RawMessage) interface{} {
+			return map[string]interface{}{"itemids": map[string]string{"0": "1", "a": "2"}}
+		},
+	})
+
+	groups := make(HostGroups, 12)
+	for i := range groups {
+		groups[i].Name = "g" + strconv.Itoa(i)
+	}
+	if err := api.HostGroupsCreate(groups); err != nil {
+		t.Fatal(err)
+	}
+	for i, g := range groups {
+		if g.GroupId != strconv.Itoa(100+i) {
+			t.Errorf("group %d: unexpected Id %q", i, g.GroupId)
+		}
+	}
+
+	if err := api.ItemsCreate(Items{{Name: "a"}, {Name: "b"}}); err == nil {
+		t.Error("Expected error for bad position in Ids object")
+	}
+}
+
+func TestObjectsMalformedResponse(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"host.get":    func(params json.RawMessage) interface{} { return map[string]string{"hostid": "1"} },
+		"host.create": func(params json.RawMessage) interface{} { return []string{"1"} },
+		"item.get": func(params json.RawMessage) interface{} {
+			return []map[string]string{{"itemid": "1", "type": "not a number"}}
+		},
+		"application.create": func(params json.RawMessage) interface{} {
+			return map[string]interface{}{"applicationids": []interface{}{nil}}
+		},
+	})
+
+	if _, err := api.HostsGet(Params{}); err == nil {
+		t.Error("Expected error for object result of host.get")
+	}
+	if err := api.HostsCreate(Hosts{{Host: "h"}}); err == nil {
+		t.Error("Expected error for array result of host.create")
+	}
+	if _, err := api.ItemsGet(Params{}); err == nil {
+		t.Error("Expected error for bad item type")
+	}
+	if err := api.ApplicationsCreate(Applications{{Name: "a"}}); err == nil {
+		t.Error("Expected error for null Id")
+	}
+}
+
+func TestObjectsUpdate(t *testing.T) {
+	var updated []map[string]interface{}
+	var massAdd Params
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"host.update": func(params json.RawMessage) interface{} {
+			if err := json.Unmarshal(params, &updated); err != nil {
+				t.Error(err)
+				return nil
+			}
+			return map[string]interface{}{"hostids": []string{"1"}}
+		},
+		"host.massadd": func(params json.RawMessage) interface{} {
+			massAdd = decodeParams(t, params)
+			return map[string]interface{}{"hostids": []string{"1", "2"}}
+		},
+	})
+
+	hosts := Hosts{{HostId: "1", Name: "new name", Available: Available, Error: "x"}}
+	if err := api.HostsUpdate(hosts, "status"); err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"hostid":"1","name":"new name","status":0}]`
+	if b, _ := json.Marshal(updated); string(b) != expected {
+		t.Errorf("Unexpected update params:\n%s\n%s", b, expected)
+	}
+
+	if err := api.HostsMassAdd([]string{"1", "2"}, HostMass{GroupIds: []string{"5"}}); err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"groups":[{"groupid":"5"}],"hosts":[{"hostid":"1"},{"hostid":"2"}]}`
+	if b, _ := json.Marshal(massAdd); string(b) != expected {
+		t.Errorf("Unexpected massadd params:\n%s\n%s", b, expected)
+	}
+}
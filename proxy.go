@@ -0,0 +1,266 @@
+package zabbix
+
+import "net"
+
+type (
+	ProxyOperatingMode int
+	ProxyCompatibility int
+	ProxyState         int
+	ProxyGroupState    int
+)
+
+const (
+	ProxyActive  ProxyOperatingMode = 0
+	ProxyPassive ProxyOperatingMode = 1
+)
+
+// "status" of proxy before Zabbix 7.0 is operating mode plus proxyStatusActive.
+const proxyStatusActive = 5
+
+// Compatibility of proxy version with server version (Zabbix 6.0+).
+const (
+	ProxyCompatibilityUndefined   ProxyCompatibility = 0
+	ProxyCompatibilityCurrent     ProxyCompatibility = 1
+	ProxyCompatibilityOutdated    ProxyCompatibility = 2
+	ProxyCompatibilityUnsupported ProxyCompatibility = 3
+)
+
+const (
+	ProxyStateUnknown ProxyState = 0
+	ProxyStateOffline ProxyState = 1
+	ProxyStateOnline  ProxyState = 2
+)
+
+const (
+	ProxyGroupStateUnknown    ProxyGroupState = 0
+	ProxyGroupStateOffline    ProxyGroupState = 1
+	ProxyGroupStateRecovering ProxyGroupState = 2
+	ProxyGroupStateOnline     ProxyGroupState = 3
+	ProxyGroupStateDegrading  ProxyGroupState = 4
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/proxy/object
+// Fields are named after Zabbix 7.0 and translated for earlier versions: Name is sent as "host",
+// OperatingMode as "status", AllowedAddresses as "proxy_address", and Address and Port of passive proxy as "interface".
+type Proxy struct {
+	ProxyId          string             `json:"proxyid,omitempty"`
+	Name             string             `json:"name"`
+	OperatingMode    ProxyOperatingMode `json:"operating_mode"`
+	Description      string             `json:"description,omitempty"`
+	AllowedAddresses string             `json:"allowed_addresses,omitempty"` // active proxies
+	Address          string             `json:"address,omitempty"`           // passive proxies, IP or DNS name
+	Port             string             `json:"port,omitempty"`              // passive proxies
+
+	ProxyGroupId string `json:"proxy_groupid,omitempty"` // Zabbix 7.0+
+	LocalAddress string `json:"local_address,omitempty"` // address for agents in proxy group, Zabbix 7.0+
+	LocalPort    string `json:"local_port,omitempty"`    // Zabbix 7.0+
+
+	TLSConnect     TLSConnection `json:"tls_connect,omitempty"`
+	TLSAccept      TLSConnection `json:"tls_accept,omitempty"`
+	TLSIssuer      string        `json:"tls_issuer,omitempty"`
+	TLSSubject     string        `json:"tls_subject,omitempty"`
+	TLSPSKIdentity string        `json:"tls_psk_identity,omitempty"` // write-only since Zabbix 5.4
+	TLSPSK         string        `json:"tls_psk,omitempty"`          // write-only since Zabbix 5.4
+
+	LastAccess    int64              `json:"lastaccess,omitempty" zabbix:"readonly"`    // Unix time
+	Version       int                `json:"version,omitempty" zabbix:"readonly"`       // e.g. 60400 for 6.4.0, Zabbix 6.0+
+	Compatibility ProxyCompatibility `json:"compatibility,omitempty" zabbix:"readonly"` // Zabbix 6.0+
+	State         ProxyState         `json:"state,omitempty" zabbix:"readonly"`         // Zabbix 7.0+
+
+	Hosts Hosts `json:"hosts,omitempty" zabbix:"readonly"` // use "selectHosts" to get them, see HostsSetProxy
+}
+
+type Proxies []Proxy
+
+// Decodes proxy across Zabbix versions: fills Name from "host", OperatingMode from "status",
+// AllowedAddresses from "proxy_address", and Address and Port from "interface" before Zabbix 7.0.
+func (p *Proxy) UnmarshalJSON(b []byte) error {
+	type proxy Proxy
+	var v struct {
+		proxy
+		Host         string `json:"host"`
+		Status       *int   `json:"status"`
+		ProxyAddress string `json:"proxy_address"`
+		Interface    *struct {
+			IP    string `json:"ip"`
+			DNS   string `json:"dns"`
+			UseIP int    `json:"useip"`
+			Port  string `json:"port"`
+		} `json:"interface"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*p = Proxy(v.proxy)
+	if p.Name == "" {
+		p.Name = v.Host
+	}
+	if v.Status != nil {
+		p.OperatingMode = ProxyOperatingMode(*v.Status - proxyStatusActive)
+	}
+	if p.AllowedAddresses == "" {
+		p.AllowedAddresses = v.ProxyAddress
+	}
+	if i := v.Interface; i != nil && p.Address == "" {
+		p.Address, p.Port = i.DNS, i.Port
+		if i.UseIP == 1 {
+			p.Address = i.IP
+		}
+	}
+	return nil
+}
+
+func (p *Proxy) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "proxy", IdField: "proxyid"} }
+func (p *Proxy) ObjectId() string       { return p.ProxyId }
+func (p *Proxy) SetObjectId(id string)  { p.ProxyId = id }
+
+// Translates operating mode and address to "status" and "interface" before Zabbix 7.0.
+func (p *Proxy) prepare(v *VersionInfo, m map[string]interface{}) {
+	if v.Supports(FeatureProxyOperatingMode) {
+		return
+	}
+	if _, present := m["operating_mode"]; present {
+		delete(m, "operating_mode")
+		m["status"] = proxyStatusActive + int(p.OperatingMode)
+	}
+	_, address := m["address"]
+	_, port := m["port"]
+	delete(m, "address")
+	delete(m, "port")
+	if (address || port) && p.OperatingMode == ProxyPassive {
+		i := map[string]interface{}{"useip": 0, "ip": "", "dns": p.Address, "port": p.Port}
+		if net.ParseIP(p.Address) != nil {
+			i["useip"], i["ip"], i["dns"] = 1, p.Address, ""
+		}
+		if p.Port == "" {
+			i["port"] = "10051"
+		}
+		m["interface"] = i
+	}
+}
+
+// Wrapper for proxy.get: https://www.zabbix.com/documentation/current/manual/api/reference/proxy/get
+func (api *API) ProxiesGet(params Params) (res Proxies, err error) {
+	return GetObjects[Proxy](api, params)
+}
+
+// Gets proxy by Id only if there is exactly 1 matching proxy.
+func (api *API) ProxyGetById(id string) (res *Proxy, err error) {
+	return GetObject[Proxy](api, Params{"proxyids": id})
+}
+
+// Gets proxy by name only if there is exactly 1 matching proxy. Filters by "host" before Zabbix 7.0.
+func (api *API) ProxyGetByName(name string) (res *Proxy, err error) {
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	field := "name"
+	if !v.Supports(FeatureProxyOperatingMode) {
+		field = "host"
+	}
+	return GetObject[Proxy](api, Params{"filter": map[string]string{field: name}})
+}
+
+// Wrapper for proxy.create: https://www.zabbix.com/documentation/current/manual/api/reference/proxy/create
+func (api *API) ProxiesCreate(proxies Proxies) (err error) {
+	return CreateObjects[Proxy](api, proxies)
+}
+
+// Wrapper for proxy.update: https://www.zabbix.com/documentation/current/manual/api/reference/proxy/update
+// Before Zabbix 7.0 OperatingMode must be set to update Address and Port of passive proxy.
+func (api *API) ProxiesUpdate(proxies Proxies, fields ...string) (err error) {
+	return UpdateObjects[Proxy](api, proxies, fields...)
+}
+
+// Wrapper for proxy.delete: https://www.zabbix.com/documentation/current/manual/api/reference/proxy/delete
+// Cleans ProxyId in all proxies elements if call succeed.
+func (api *API) ProxiesDelete(proxies Proxies) (err error) {
+	return DeleteObjects[Proxy](api, proxies)
+}
+
+// Wrapper for proxy.delete: https://www.zabbix.com/documentation/current/manual/api/reference/proxy/delete
+func (api *API) ProxiesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Proxy](api, ids)
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/object
+type ProxyGroup struct {
+	ProxyGroupId  string          `json:"proxy_groupid,omitempty"`
+	Name          string          `json:"name"`
+	FailoverDelay string          `json:"failover_delay,omitempty"` // e.g. "1m"
+	MinOnline     string          `json:"min_online,omitempty"`     // may be a macro
+	Description   string          `json:"description,omitempty"`
+	State         ProxyGroupState `json:"state,omitempty" zabbix:"readonly"`
+
+	Proxies Proxies `json:"proxies,omitempty" zabbix:"readonly"` // use "selectProxies" to get them
+}
+
+type ProxyGroups []ProxyGroup
+
+func (g *ProxyGroup) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "proxygroup", IdField: "proxy_groupid", Requires: FeatureProxyGroups}
+}
+func (g *ProxyGroup) ObjectId() string      { return g.ProxyGroupId }
+func (g *ProxyGroup) SetObjectId(id string) { g.ProxyGroupId = id }
+
+// Wrapper for proxygroup.get (Zabbix 7.0+): https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/get
+func (api *API) ProxyGroupsGet(params Params) (res ProxyGroups, err error) {
+	return GetObjects[ProxyGroup](api, params)
+}
+
+// Gets proxy group by Id only if there is exactly 1 matching proxy group.
+func (api *API) ProxyGroupGetById(id string) (res *ProxyGroup, err error) {
+	return GetObject[ProxyGroup](api, Params{"proxy_groupids": id})
+}
+
+// Wrapper for proxygroup.create: https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/create
+func (api *API) ProxyGroupsCreate(groups ProxyGroups) (err error) {
+	return CreateObjects[ProxyGroup](api, groups)
+}
+
+// Wrapper for proxygroup.update: https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/update
+func (api *API) ProxyGroupsUpdate(groups ProxyGroups, fields ...string) (err error) {
+	return UpdateObjects[ProxyGroup](api, groups, fields...)
+}
+
+// Wrapper for proxygroup.delete: https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/delete
+// Cleans ProxyGroupId in all groups elements if call succeed.
+func (api *API) ProxyGroupsDelete(groups ProxyGroups) (err error) {
+	return DeleteObjects[ProxyGroup](api, groups)
+}
+
+// Wrapper for proxygroup.delete: https://www.zabbix.com/documentation/current/manual/api/reference/proxygroup/delete
+func (api *API) ProxyGroupsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[ProxyGroup](api, ids)
+}
+
+// Makes hosts monitored by proxy with host.massupdate, or by server if proxyId is empty.
+func (api *API) HostsSetProxy(hostIds []string, proxyId string) (err error) {
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	var p Params
+	switch {
+	case !v.Supports(FeatureProxyGroups) && proxyId == "":
+		p = Params{"proxy_hostid": "0"}
+	case !v.Supports(FeatureProxyGroups):
+		p = Params{"proxy_hostid": proxyId}
+	case proxyId == "":
+		p = Params{"monitored_by": MonitoredByServer}
+	default:
+		p = Params{"monitored_by": MonitoredByProxy, "proxyid": proxyId}
+	}
+	return api.HostsMassUpdate(hostIds, HostMass{Params: p})
+}
+
+// Makes hosts monitored by proxy group (Zabbix 7.0+) with host.massupdate.
+func (api *API) HostsSetProxyGroup(hostIds []string, proxyGroupId string) (err error) {
+	if err = api.require(FeatureProxyGroups); err != nil {
+		return
+	}
+	p := Params{"monitored_by": MonitoredByProxyGroup, "proxy_groupid": proxyGroupId}
+	return api.HostsMassUpdate(hostIds, HostMass{Params: p})
+}
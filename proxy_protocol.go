@@ -0,0 +1,253 @@
+package zabbix
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// Protocol version reported by ProxyClient by default. Server accepts data only from proxies of compatible version.
+const ProxyProtocolVersion = "7.0.0"
+
+// Item value collected by proxy.
+type ProxyHistoryValue struct {
+	Id     uint64 `json:"id"` // increasing number, set by ProxyClient.SendData if 0
+	ItemId uint64 `json:"itemid"`
+	Clock  int64  `json:"clock"` // set to current time by ProxyClient.SendData if 0
+	Ns     int64  `json:"ns"`
+	Value  string `json:"value,omitempty"`
+	State  int    `json:"state,omitempty"` // 1 for not supported item, Value is error message
+
+	// Log items
+	LastLogSize int64  `json:"lastlogsize,omitempty"`
+	MTime       int64  `json:"mtime,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Severity    int    `json:"severity,omitempty"`
+	LogEventId  int    `json:"logeventid,omitempty"`
+}
+
+// Result of network discovery check.
+type ProxyDiscoveryValue struct {
+	Clock    int64  `json:"clock"`
+	DRuleId  uint64 `json:"drule"`
+	DCheckId uint64 `json:"dcheck,omitempty"` // 0 for host record
+	IP       string `json:"ip"`
+	DNS      string `json:"dns,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Status   int    `json:"status"` // 0 - up, 1 - down
+}
+
+// Autoregistration request of active agent received by proxy.
+type ProxyAutoregistration struct {
+	Clock        int64         `json:"clock"`
+	Host         string        `json:"host"`
+	IP           string        `json:"ip,omitempty"`
+	DNS          string        `json:"dns,omitempty"`
+	Port         string        `json:"port,omitempty"`
+	HostMetadata string        `json:"host_metadata,omitempty"`
+	TLSAccepted  TLSConnection `json:"tls_accepted,omitempty"`
+	Flags        int           `json:"flags,omitempty"`
+}
+
+// Availability of host interface checked by proxy.
+type ProxyInterfaceAvailability struct {
+	InterfaceId uint64        `json:"interfaceid"`
+	Available   AvailableType `json:"available"`
+	Error       string        `json:"error"`
+}
+
+// Status of active checks of host (Zabbix 6.4+).
+type ProxyHostData struct {
+	HostId       uint64 `json:"hostid"`
+	ActiveStatus int    `json:"active_status"` // 0 - unknown, 1 - available, 2 - unavailable
+}
+
+// Data sent by active proxy in "proxy data" request, empty sections are omitted.
+type ProxyData struct {
+	History               []ProxyHistoryValue          `json:"history data,omitempty"`
+	Discovery             []ProxyDiscoveryValue        `json:"discovery data,omitempty"`
+	Autoregistration      []ProxyAutoregistration      `json:"auto registration,omitempty"`
+	InterfaceAvailability []ProxyInterfaceAvailability `json:"interface availability,omitempty"`
+	HostData              []ProxyHostData              `json:"host data,omitempty"`
+	More                  bool                         `json:"-"` // proxy has more data to send
+}
+
+// Response of server to "proxy data" request.
+type ProxyDataResponse struct {
+	Response string            `json:"response"`
+	Info     string            `json:"info,omitempty"`
+	Upload   string            `json:"upload,omitempty"` // "disabled" when server history cache is full, Zabbix 6.4+
+	Tasks    []json.RawMessage `json:"tasks,omitempty"`  // remote commands and other tasks for proxy
+}
+
+// Table of proxy configuration: field names and rows of values, numbers are decoded as json.Number.
+type ProxyConfigTable struct {
+	Fields []string        `json:"fields"`
+	Data   [][]interface{} `json:"data"`
+}
+
+// Returns rows of table as maps by field name.
+func (t *ProxyConfigTable) Rows() []map[string]interface{} {
+	res := make([]map[string]interface{}, len(t.Data))
+	for i, row := range t.Data {
+		res[i] = make(map[string]interface{}, len(t.Fields))
+		for j, f := range t.Fields {
+			if j < len(row) {
+				res[i][f] = row[j]
+			}
+		}
+	}
+	return res
+}
+
+// Response of server to "proxy config" request.
+type ProxyConfig struct {
+	Revision int64                        // "config_revision", Zabbix 6.4+
+	Tables   map[string]*ProxyConfigTable // by table name, e.g. "hosts" or "items"; empty if configuration is not changed
+}
+
+// Decodes tables from "data" (Zabbix 6.4+) or from top level (earlier versions).
+func (c *ProxyConfig) UnmarshalJSON(b []byte) error {
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*c = ProxyConfig{Tables: make(map[string]*ProxyConfigTable)}
+	if raw, ok := v["config_revision"]; ok {
+		if err := json.Unmarshal(raw, &c.Revision); err != nil {
+			return fmt.Errorf("config_revision: %w", err)
+		}
+	}
+	if raw, ok := v["data"]; ok {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("data: %w", err)
+		}
+	}
+	for name, raw := range v {
+		var t ProxyConfigTable
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+		if len(raw) == 0 || raw[0] != '{' || d.Decode(&t) != nil || t.Fields == nil {
+			continue // not a table, e.g. "config_revision" or "macro.secrets"
+		}
+		c.Tables[name] = &t
+	}
+	return nil
+}
+
+// ProxyClient emulates active Zabbix proxy: requests configuration and sends collected data to server.
+// Proxy with the same name must be configured on server in active mode.
+type ProxyClient struct {
+	Server    string        // Zabbix server address
+	Port      int           // Zabbix server port (default: 10051)
+	Host      string        // proxy name
+	Version   string        // protocol version (default: ProxyProtocolVersion)
+	Session   string        // proxy session token, random by default
+	Compress  bool          // compress requests with zlib (default: true)
+	Timeout   time.Duration // connection timeout (default: 5 seconds)
+	TLSConfig *tls.Config   // certificate-based connection, nil for unencrypted
+	Logger    *log.Logger   // Logger for debugging
+
+	// Last received configuration revision, sent with next "proxy config" request to get only changes.
+	ConfigRevision int64
+
+	lastId uint64
+}
+
+// NewProxyClient creates a new ProxyClient instance
+func NewProxyClient(server string, port int, host string) *ProxyClient {
+	if port == 0 {
+		port = 10051
+	}
+	session := make([]byte, 16)
+	rand.Read(session)
+	return &ProxyClient{
+		Server:   server,
+		Port:     port,
+		Host:     host,
+		Version:  ProxyProtocolVersion,
+		Session:  hex.EncodeToString(session),
+		Compress: true,
+		Timeout:  5 * time.Second,
+	}
+}
+
+func (c *ProxyClient) conn() *zbxdConn {
+	return &zbxdConn{
+		address:   net.JoinHostPort(c.Server, fmt.Sprintf("%d", c.Port)),
+		timeout:   c.Timeout,
+		tlsConfig: c.TLSConfig,
+		compress:  c.Compress,
+		printf: func(format string, v ...interface{}) {
+			if c.Logger != nil {
+				c.Logger.Printf(format, v...)
+			}
+		},
+	}
+}
+
+// Config sends "proxy config" request and returns configuration changed since ConfigRevision,
+// which is updated from response.
+func (c *ProxyClient) Config() (res *ProxyConfig, err error) {
+	req := map[string]interface{}{
+		"request": "proxy config",
+		"host":    c.Host,
+		"version": c.Version,
+		"session": c.Session,
+	}
+	if c.ConfigRevision != 0 {
+		req["config_revision"] = c.ConfigRevision
+	}
+	res = new(ProxyConfig)
+	if err = c.conn().exchange("proxy config", req, res); err != nil {
+		return nil, err
+	}
+	if res.Revision != 0 {
+		c.ConfigRevision = res.Revision
+	}
+	return
+}
+
+// SendData sends "proxy data" request. History values without Id get increasing Ids,
+// values without Clock get current time.
+func (c *ProxyClient) SendData(data *ProxyData) (res *ProxyDataResponse, err error) {
+	now := time.Now()
+	for i := range data.History {
+		h := &data.History[i]
+		if h.Id == 0 {
+			h.Id = atomic.AddUint64(&c.lastId, 1)
+		}
+		if h.Clock == 0 {
+			h.Clock, h.Ns = now.Unix(), int64(now.Nanosecond())
+		}
+	}
+
+	var req map[string]interface{}
+	if err = jsonParams(data, &req); err != nil {
+		return nil, fmt.Errorf("failed to marshal proxy data: %w", err)
+	}
+	req["request"] = "proxy data"
+	req["host"] = c.Host
+	req["version"] = c.Version
+	req["session"] = c.Session
+	req["clock"] = now.Unix()
+	req["ns"] = now.Nanosecond()
+	if data.More {
+		req["more"] = 1
+	}
+
+	res = new(ProxyDataResponse)
+	if err = c.conn().exchange("proxy data", req, res); err != nil {
+		return nil, err
+	}
+	return
+}
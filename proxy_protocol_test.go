@@ -0,0 +1,171 @@
+package zabbix_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+// Starts ZBXD server which decodes requests and responds with handler result.
+// Returns host and port of server.
+func newFakeZBXD(t *testing.T, handler func(flags byte, req map[string]interface{}) interface{}) (string, int) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			func() {
+				defer conn.Close()
+				header := make([]byte, 13)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					t.Errorf("failed to read header: %s", err)
+					return
+				}
+				data := make([]byte, binary.LittleEndian.Uint32(header[5:9]))
+				if _, err := io.ReadFull(conn, data); err != nil {
+					t.Errorf("failed to read data: %s", err)
+					return
+				}
+				if header[4]&0x02 != 0 {
+					r, err := zlib.NewReader(bytes.NewReader(data))
+					if err != nil {
+						t.Errorf("failed to decompress: %s", err)
+						return
+					}
+					if data, err = io.ReadAll(r); err != nil {
+						t.Errorf("failed to decompress: %s", err)
+						return
+					}
+					if len(data) != int(binary.LittleEndian.Uint32(header[9:13])) {
+						t.Errorf("unexpected uncompressed length %d", len(data))
+					}
+				}
+
+				d := json.NewDecoder(bytes.NewReader(data))
+				d.UseNumber()
+				var req map[string]interface{}
+				if err := d.Decode(&req); err != nil {
+					t.Errorf("bad request %s: %s", data, err)
+					return
+				}
+				b, _ := json.Marshal(handler(header[4], req))
+
+				res := make([]byte, 13, 13+len(b))
+				copy(res, "ZBXD\x01")
+				binary.LittleEndian.PutUint32(res[5:9], uint32(len(b)))
+				conn.Write(append(res, b...))
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p
+}
+
+func TestProxyClientConfig(t *testing.T) {
+	var revisions []interface{}
+	host, port := newFakeZBXD(t, func(flags byte, req map[string]interface{}) interface{} {
+		if req["request"] != "proxy config" || req["host"] != "proxy1" || req["version"] != ProxyProtocolVersion {
+			t.Errorf("unexpected request %v", req)
+		}
+		revisions = append(revisions, req["config_revision"])
+		return map[string]interface{}{
+			"config_revision": 42,
+			"data": map[string]interface{}{
+				"hosts": map[string]interface{}{
+					"fields": []string{"hostid", "host", "status"},
+					"data":   [][]interface{}{{10084, "web01", 0}},
+				},
+			},
+			"macro.secrets": map[string]interface{}{},
+		}
+	})
+
+	c := NewProxyClient(host, port, "proxy1")
+	for i := 0; i < 2; i++ {
+		config, err := c.Config()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(config.Tables) != 1 || config.Tables["hosts"] == nil {
+			t.Fatalf("unexpected tables %v", config.Tables)
+		}
+		rows := config.Tables["hosts"].Rows()
+		if len(rows) != 1 || rows[0]["host"] != "web01" || rows[0]["hostid"] != json.Number("10084") {
+			t.Errorf("unexpected rows %v", rows)
+		}
+	}
+	if c.ConfigRevision != 42 || revisions[0] != nil || revisions[1] != json.Number("42") {
+		t.Errorf("unexpected revisions %d, %v", c.ConfigRevision, revisions)
+	}
+}
+
+func TestProxyClientSendData(t *testing.T) {
+	host, port := newFakeZBXD(t, func(flags byte, req map[string]interface{}) interface{} {
+		if flags != 0x03 {
+			t.Errorf("expected compressed request, got flags %#x", flags)
+		}
+		history, _ := req["history data"].([]interface{})
+		if req["request"] != "proxy data" || len(history) != 2 || req["session"] == "" {
+			t.Errorf("unexpected request %v", req)
+		}
+		for _, key := range []string{"discovery data", "interface availability", "more"} {
+			if _, present := req[key]; present {
+				t.Errorf("unexpected %q in %v", key, req)
+			}
+		}
+		for i, h := range history {
+			h := h.(map[string]interface{})
+			if h["id"] != json.Number(strconv.Itoa(i+1)) || h["itemid"] != json.Number("18446744073709551615") || h["clock"] == json.Number("0") {
+				t.Errorf("unexpected history value %v", h)
+			}
+		}
+		if ar := req["auto registration"].([]interface{})[0].(map[string]interface{}); ar["host_metadata"] != "Linux" {
+			t.Errorf("unexpected autoregistration %v", ar)
+		}
+		return map[string]interface{}{"response": "success", "upload": "enabled"}
+	})
+
+	c := NewProxyClient(host, port, "proxy1")
+	res, err := c.SendData(&ProxyData{
+		History: []ProxyHistoryValue{
+			{ItemId: 18446744073709551615, Value: "1"},
+			{ItemId: 18446744073709551615, Value: "2"},
+		},
+		Autoregistration: []ProxyAutoregistration{{Clock: 1700000000, Host: "web02", IP: "10.0.0.2", HostMetadata: "Linux"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Response != "success" || res.Upload != "enabled" {
+		t.Errorf("unexpected response %+v", res)
+	}
+}
+
+func TestProxyClientFailed(t *testing.T) {
+	host, port := newFakeZBXD(t, func(flags byte, req map[string]interface{}) interface{} {
+		return map[string]interface{}{"response": "failed", "info": `proxy "proxy1" not found`}
+	})
+
+	_, err := NewProxyClient(host, port, "proxy1").SendData(&ProxyData{})
+	var e *ProtocolError
+	if !errors.As(err, &e) || e.Request != "proxy data" || e.Info != `proxy "proxy1" not found` {
+		t.Errorf("expected ProtocolError, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestProxyCreateVersions(t *testing.T) {
+	for version, expected := range map[string]string{
+		"6.4.0": `[{"host":"proxy1","interface":{"dns":"","ip":"10.0.0.5","port":"10051","useip":1},"status":6,"tls_accept":1,"tls_connect":1}]`,
+		"7.0.0": `[{"address":"10.0.0.5","name":"proxy1","operating_mode":1,"tls_accept":1,"tls_connect":1}]`,
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"proxy.create": func(raw json.RawMessage) interface{} {
+				if string(raw) != expected {
+					t.Errorf("%s: expected %s, got %s", version, expected, raw)
+				}
+				return map[string]interface{}{"proxyids": []string{"7"}}
+			},
+		})
+
+		proxies := Proxies{{Name: "proxy1", OperatingMode: ProxyPassive, Address: "10.0.0.5", TLSConnect: TLSNoEncryption, TLSAccept: TLSNoEncryption}}
+		if err := api.ProxiesCreate(proxies); err != nil {
+			t.Fatal(err)
+		}
+		if proxies[0].ProxyId != "7" {
+			t.Errorf("unexpected Id %q", proxies[0].ProxyId)
+		}
+	}
+}
+
+func TestProxyDecode(t *testing.T) {
+	expected := Proxy{
+		ProxyId: "7", Name: "proxy1", OperatingMode: ProxyPassive, Address: "proxy1.example.com", Port: "10051",
+		LastAccess: 1700000000, Version: 60400, Compatibility: ProxyCompatibilityCurrent,
+	}
+	for version, result := range map[string]map[string]interface{}{
+		"6.4.0": {
+			"proxyid": "7", "host": "proxy1", "status": "6", "lastaccess": "1700000000", "version": "60400", "compatibility": "1",
+			"interface": map[string]string{"useip": "0", "ip": "", "dns": "proxy1.example.com", "port": "10051"},
+		},
+		"7.0.0": {
+			"proxyid": "7", "name": "proxy1", "operating_mode": "1", "address": "proxy1.example.com", "port": "10051",
+			"lastaccess": "1700000000", "version": "60400", "compatibility": "1",
+		},
+	} {
+		result := result
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"proxy.get": func(raw json.RawMessage) interface{} {
+				return []interface{}{result}
+			},
+		})
+		p, err := api.ProxyGetById("7")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*p, expected) {
+			t.Errorf("%s: expected %+v, got %+v", version, expected, *p)
+		}
+	}
+
+	var p Proxy
+	if err := json.Unmarshal([]byte(`{"proxyid":"8","host":"proxy2","status":"5","interface":[]}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "proxy2" || p.OperatingMode != ProxyActive || p.Address != "" {
+		t.Errorf("unexpected active proxy %+v", p)
+	}
+}
+
+func TestHostsSetProxy(t *testing.T) {
+	for _, c := range []struct {
+		version, proxyId, expected string
+	}{
+		{"6.4.0", "7", `{"hosts":[{"hostid":"10"}],"proxy_hostid":"7"}`},
+		{"6.4.0", "", `{"hosts":[{"hostid":"10"}],"proxy_hostid":"0"}`},
+		{"7.0.0", "7", `{"hosts":[{"hostid":"10"}],"monitored_by":1,"proxyid":"7"}`},
+		{"7.0.0", "", `{"hosts":[{"hostid":"10"}],"monitored_by":0}`},
+	} {
+		api := newFakeAPI(t, c.version, map[string]fakeHandler{
+			"host.massupdate": func(raw json.RawMessage) interface{} {
+				if string(raw) != c.expected {
+					t.Errorf("%s: expected %s, got %s", c.version, c.expected, raw)
+				}
+				return map[string]interface{}{"hostids": []string{"10"}}
+			},
+		})
+		if err := api.HostsSetProxy([]string{"10"}, c.proxyId); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	api := newFakeAPI(t, "6.4.0", nil)
+	if err := api.HostsSetProxyGroup([]string{"10"}, "1"); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+	if _, err := api.ProxyGroupsGet(nil); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+}
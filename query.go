@@ -0,0 +1,315 @@
+package zabbix
+
+import "time"
+
+// Output selects fields returned by get methods in "output" and "select*" parameters.
+// nil omits parameter, OutputExtend returns all fields, OutputCount returns number of
+// sub-objects (select* only), any other value lists field names.
+type Output []string
+
+var (
+	OutputExtend = Output{"extend"}
+	OutputCount  = Output{"count"}
+)
+
+func (o Output) value() interface{} {
+	if len(o) == 1 && (o[0] == "extend" || o[0] == "count") {
+		return o[0]
+	}
+	return []string(o)
+}
+
+type SortOrder string
+
+const (
+	Asc  SortOrder = "ASC"
+	Desc SortOrder = "DESC"
+)
+
+// GetQuery holds parameters common to all get methods:
+// https://www.zabbix.com/documentation/current/manual/api/reference_commentary#common-get-method-parameters
+type GetQuery struct {
+	Output          Output                 // default is OutputExtend
+	Filter          map[string]interface{} // exact match
+	Search          map[string]interface{} // LIKE "%…%" match
+	SearchByAny     bool                   // match any of Search fields instead of all
+	SearchWildcards bool                   // allow "*" wildcard in Search
+	StartSearch     bool                   // match Search at the start of field only
+	ExcludeSearch   bool                   // return objects not matching Search
+	SortField       []string
+	SortOrder       SortOrder
+	Limit           int
+	Editable        bool // return only objects with write permission
+
+	// Extra raw parameters, they override parameters set by other fields.
+	Params Params
+}
+
+// Returns parameters of q as Params, with extra Params applied last by caller.
+func (q *GetQuery) params() Params {
+	p := Params{"output": "extend"}
+	if q.Output != nil {
+		p["output"] = q.Output.value()
+	}
+	if len(q.Filter) > 0 {
+		p["filter"] = q.Filter
+	}
+	if len(q.Search) > 0 {
+		p["search"] = q.Search
+	}
+	p.setBool("searchByAny", q.SearchByAny)
+	p.setBool("searchWildcardsEnabled", q.SearchWildcards)
+	p.setBool("startSearch", q.StartSearch)
+	p.setBool("excludeSearch", q.ExcludeSearch)
+	p.setStrings("sortfield", q.SortField)
+	if q.SortOrder != "" {
+		p["sortorder"] = q.SortOrder
+	}
+	if q.Limit > 0 {
+		p["limit"] = q.Limit
+	}
+	p.setBool("editable", q.Editable)
+	return p
+}
+
+// Applies extra parameters.
+func (q *GetQuery) apply(p Params) Params {
+	for k, v := range q.Params {
+		p[k] = v
+	}
+	return p
+}
+
+func (p Params) setBool(key string, v bool) {
+	if v {
+		p[key] = true
+	}
+}
+
+func (p Params) setStrings(key string, v []string) {
+	if len(v) > 0 {
+		p[key] = v
+	}
+}
+
+func (p Params) setOutput(key string, v Output) {
+	if v != nil {
+		p[key] = v.value()
+	}
+}
+
+// Parameters of host.get: https://www.zabbix.com/documentation/current/manual/api/reference/host/get
+type HostQuery struct {
+	GetQuery
+	HostIds      []string
+	GroupIds     []string
+	TemplateIds  []string
+	ItemIds      []string
+	InterfaceIds []string
+	ProxyIds     []string
+
+	MonitoredHosts     bool // only monitored hosts
+	WithItems          bool
+	WithMonitoredItems bool
+	WithTriggers       bool
+	WithInventory      bool
+
+	SelectGroups          Output // before Zabbix 7.0, see SelectHostGroups
+	SelectHostGroups      Output // Zabbix 6.2+
+	SelectParentTemplates Output
+	SelectInterfaces      Output
+	SelectItems           Output
+	SelectTags            Output // Zabbix 4.2+
+	SelectMacros          Output
+	SelectInventory       Output
+}
+
+// Returns host.get parameters, use it with HostsGet.
+func (q HostQuery) Params() Params {
+	p := q.params()
+	p.setStrings("hostids", q.HostIds)
+	p.setStrings("groupids", q.GroupIds)
+	p.setStrings("templateids", q.TemplateIds)
+	p.setStrings("itemids", q.ItemIds)
+	p.setStrings("interfaceids", q.InterfaceIds)
+	p.setStrings("proxyids", q.ProxyIds)
+	p.setBool("monitored_hosts", q.MonitoredHosts)
+	p.setBool("with_items", q.WithItems)
+	p.setBool("with_monitored_items", q.WithMonitoredItems)
+	p.setBool("with_triggers", q.WithTriggers)
+	p.setBool("withInventory", q.WithInventory)
+	p.setOutput("selectGroups", q.SelectGroups)
+	p.setOutput("selectHostGroups", q.SelectHostGroups)
+	p.setOutput("selectParentTemplates", q.SelectParentTemplates)
+	p.setOutput("selectInterfaces", q.SelectInterfaces)
+	p.setOutput("selectItems", q.SelectItems)
+	p.setOutput("selectTags", q.SelectTags)
+	p.setOutput("selectMacros", q.SelectMacros)
+	p.setOutput("selectInventory", q.SelectInventory)
+	return q.apply(p)
+}
+
+// Parameters of hostgroup.get: https://www.zabbix.com/documentation/current/manual/api/reference/hostgroup/get
+type HostGroupQuery struct {
+	GetQuery
+	GroupIds []string
+	HostIds  []string
+
+	MonitoredHosts bool // only groups containing monitored hosts
+	RealHosts      bool // only groups containing hosts
+	WithItems      bool
+
+	SelectHosts Output
+}
+
+// Returns hostgroup.get parameters, use it with HostGroupsGet.
+func (q HostGroupQuery) Params() Params {
+	p := q.params()
+	p.setStrings("groupids", q.GroupIds)
+	p.setStrings("hostids", q.HostIds)
+	p.setBool("monitored_hosts", q.MonitoredHosts)
+	p.setBool("real_hosts", q.RealHosts)
+	p.setBool("with_items", q.WithItems)
+	p.setOutput("selectHosts", q.SelectHosts)
+	return q.apply(p)
+}
+
+// Parameters of item.get: https://www.zabbix.com/documentation/current/manual/api/reference/item/get
+type ItemQuery struct {
+	GetQuery
+	ItemIds        []string
+	HostIds        []string
+	GroupIds       []string
+	TemplateIds    []string
+	InterfaceIds   []string
+	ApplicationIds []string // Zabbix before 5.4 only
+
+	Monitored bool // only enabled items on monitored hosts
+	WebItems  bool // include web scenario items
+
+	SelectHosts    Output
+	SelectTriggers Output
+}
+
+// Returns item.get parameters, use it with ItemsGet.
+func (q ItemQuery) Params() Params {
+	p := q.params()
+	p.setStrings("itemids", q.ItemIds)
+	p.setStrings("hostids", q.HostIds)
+	p.setStrings("groupids", q.GroupIds)
+	p.setStrings("templateids", q.TemplateIds)
+	p.setStrings("interfaceids", q.InterfaceIds)
+	p.setStrings("applicationids", q.ApplicationIds)
+	p.setBool("monitored", q.Monitored)
+	p.setBool("webitems", q.WebItems)
+	p.setOutput("selectHosts", q.SelectHosts)
+	p.setOutput("selectTriggers", q.SelectTriggers)
+	return q.apply(p)
+}
+
+// Parameters of application.get: https://www.zabbix.com/documentation/4.0/manual/api/reference/application/get
+type ApplicationQuery struct {
+	GetQuery
+	ApplicationIds []string
+	HostIds        []string
+	GroupIds       []string
+	ItemIds        []string
+
+	SelectItems Output
+}
+
+// Returns application.get parameters, use it with ApplicationsGet.
+func (q ApplicationQuery) Params() Params {
+	p := q.params()
+	p.setStrings("applicationids", q.ApplicationIds)
+	p.setStrings("hostids", q.HostIds)
+	p.setStrings("groupids", q.GroupIds)
+	p.setStrings("itemids", q.ItemIds)
+	p.setOutput("selectItems", q.SelectItems)
+	return q.apply(p)
+}
+
+// Parameters of hostinterface.get: https://www.zabbix.com/documentation/current/manual/api/reference/hostinterface/get
+type HostInterfaceQuery struct {
+	GetQuery
+	InterfaceIds []string
+	HostIds      []string
+	ItemIds      []string
+}
+
+// Returns hostinterface.get parameters, use it with HostInterfacesGet.
+func (q HostInterfaceQuery) Params() Params {
+	p := q.params()
+	p.setStrings("interfaceids", q.InterfaceIds)
+	p.setStrings("hostids", q.HostIds)
+	p.setStrings("itemids", q.ItemIds)
+	return q.apply(p)
+}
+
+// Parameters of history.get: https://www.zabbix.com/documentation/current/manual/api/reference/history/get
+// History value type is passed separately to HistoryGetTyped.
+type HistoryQuery struct {
+	GetQuery
+	ItemIds  []string
+	HostIds  []string
+	TimeFrom time.Time
+	TimeTill time.Time
+}
+
+// Returns history.get parameters, use it with HistoryGetTyped or HistoryGetByItems.
+func (q HistoryQuery) Params() Params {
+	p := q.params()
+	p.setStrings("itemids", q.ItemIds)
+	p.setStrings("hostids", q.HostIds)
+	if !q.TimeFrom.IsZero() {
+		p["time_from"] = q.TimeFrom.Unix()
+	}
+	if !q.TimeTill.IsZero() {
+		p["time_till"] = q.TimeTill.Unix()
+	}
+	return q.apply(p)
+}
+
+// Parameters of alert.get: https://www.zabbix.com/documentation/current/manual/api/reference/alert/get
+type AlertQuery struct {
+	GetQuery
+	AlertIds     []string
+	ActionIds    []string
+	EventIds     []string
+	GroupIds     []string
+	HostIds      []string
+	UserIds      []string
+	MediaTypeIds []string
+	EventSource  *EventSource
+	TimeFrom     time.Time
+	TimeTill     time.Time
+
+	SelectHosts      Output
+	SelectMediaTypes Output
+	SelectUsers      Output
+}
+
+// Returns alert.get parameters, use it with AlertsGet.
+func (q AlertQuery) Params() Params {
+	p := q.params()
+	p.setStrings("alertids", q.AlertIds)
+	p.setStrings("actionids", q.ActionIds)
+	p.setStrings("eventids", q.EventIds)
+	p.setStrings("groupids", q.GroupIds)
+	p.setStrings("hostids", q.HostIds)
+	p.setStrings("userids", q.UserIds)
+	p.setStrings("mediatypeids", q.MediaTypeIds)
+	if q.EventSource != nil {
+		p["eventsource"] = *q.EventSource
+	}
+	if !q.TimeFrom.IsZero() {
+		p["time_from"] = q.TimeFrom.Unix()
+	}
+	if !q.TimeTill.IsZero() {
+		p["time_till"] = q.TimeTill.Unix()
+	}
+	p.setOutput("selectHosts", q.SelectHosts)
+	p.setOutput("selectMediatypes", q.SelectMediaTypes)
+	p.setOutput("selectUsers", q.SelectUsers)
+	return q.apply(p)
+}
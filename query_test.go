@@ -0,0 +1,55 @@
+package zabbix_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestHostQueryParams(t *testing.T) {
+	q := HostQuery{
+		GetQuery: GetQuery{
+			Output:          Output{"hostid", "host"},
+			Search:          map[string]interface{}{"host": "web*"},
+			SearchWildcards: true,
+			SortField:       []string{"host"},
+			SortOrder:       Desc,
+			Limit:           10,
+			Params:          Params{"limit": 20, "tags": []string{"x"}},
+		},
+		GroupIds:         []string{"4"},
+		MonitoredHosts:   true,
+		SelectInterfaces: OutputExtend,
+		SelectGroups:     OutputCount,
+	}
+	expected := Params{
+		"output":                 []string{"hostid", "host"},
+		"search":                 map[string]interface{}{"host": "web*"},
+		"searchWildcardsEnabled": true,
+		"sortfield":              []string{"host"},
+		"sortorder":              Desc,
+		"limit":                  20,
+		"tags":                   []string{"x"},
+		"groupids":               []string{"4"},
+		"monitored_hosts":        true,
+		"selectInterfaces":       "extend",
+		"selectGroups":           "count",
+	}
+	if p := q.Params(); !reflect.DeepEqual(p, expected) {
+		t.Errorf("Unexpected params:\n%#v\n%#v", p, expected)
+	}
+}
+
+func TestQueryDefaults(t *testing.T) {
+	if p := (ItemQuery{}).Params(); !reflect.DeepEqual(p, Params{"output": "extend"}) {
+		t.Errorf("Unexpected params: %#v", p)
+	}
+
+	q := HistoryQuery{ItemIds: []string{"1"}, TimeFrom: time.Unix(10, 0)}
+	expected := Params{"output": "extend", "itemids": []string{"1"}, "time_from": int64(10)}
+	if p := q.Params(); !reflect.DeepEqual(p, expected) {
+		t.Errorf("Unexpected params: %#v", p)
+	}
+}
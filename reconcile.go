@@ -0,0 +1,719 @@
+package zabbix
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredState describes host groups, hosts and items managed by Reconciler.
+// It may be built in Go, decoded from JSON or loaded from YAML with LoadDesiredState.
+type DesiredState struct {
+	Groups []string      `json:"groups,omitempty" yaml:"groups,omitempty"` // groups used by hosts are added automatically
+	Hosts  []DesiredHost `json:"hosts" yaml:"hosts"`
+}
+
+// Decodes desired state from YAML, or JSON as its subset. Unknown fields are rejected.
+func LoadDesiredState(r io.Reader) (res *DesiredState, err error) {
+	d := yaml.NewDecoder(r)
+	d.KnownFields(true)
+	res = new(DesiredState)
+	if err = d.Decode(res); err != nil {
+		return nil, fmt.Errorf("failed to decode desired state: %w", err)
+	}
+	return
+}
+
+type DesiredHost struct {
+	Host       string             `json:"host" yaml:"host"`
+	Name       string             `json:"name,omitempty" yaml:"name,omitempty"` // default is Host
+	Status     StatusType         `json:"status,omitempty" yaml:"status,omitempty"`
+	Groups     []string           `json:"groups" yaml:"groups"` // names of host groups
+	Interfaces []DesiredInterface `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	Tags       Tags               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Items      []DesiredItem      `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// Host interface, the first interface of each type is the main one.
+type DesiredInterface struct {
+	Type    InterfaceType     `json:"type" yaml:"type"`
+	IP      string            `json:"ip,omitempty" yaml:"ip,omitempty"` // used if not empty, DNS otherwise
+	DNS     string            `json:"dns,omitempty" yaml:"dns,omitempty"`
+	Port    string            `json:"port,omitempty" yaml:"port,omitempty"` // default port of interface type if empty
+	Details *InterfaceDetails `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// Item, interface is selected by item type from main host interfaces.
+type DesiredItem struct {
+	Key         string    `json:"key" yaml:"key"`
+	Name        string    `json:"name" yaml:"name"`
+	Type        ItemType  `json:"type" yaml:"type"`
+	ValueType   ValueType `json:"value_type" yaml:"value_type"`
+	Delay       string    `json:"delay,omitempty" yaml:"delay,omitempty"`
+	Units       string    `json:"units,omitempty" yaml:"units,omitempty"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        Tags      `json:"tags,omitempty" yaml:"tags,omitempty"` // Zabbix 5.4+
+}
+
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	ChangeDelete ChangeKind = "delete"
+)
+
+// Change of one object in Plan.
+type Change struct {
+	Kind   ChangeKind
+	Object string   // "hostgroup", "host" or "item"
+	Name   string   // group name, host name or item key
+	Host   string   // host name of item
+	Fields []string // JSON names of changed fields for ChangeUpdate
+
+	id    string // Id of existing object
+	host  *DesiredHost
+	item  *DesiredItem
+	group string
+}
+
+func (c *Change) String() string {
+	sign := map[ChangeKind]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeDelete: "-"}[c.Kind]
+	s := fmt.Sprintf("%s %s %q", sign, c.Object, c.Name)
+	if c.Host != "" {
+		s += fmt.Sprintf(" on %q", c.Host)
+	}
+	if len(c.Fields) > 0 {
+		s += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	return s
+}
+
+// Plan is a list of changes computed by Reconciler.Plan, in order of application.
+type Plan struct {
+	Changes []Change
+
+	groupIds map[string]string // by name
+	hostIds  map[string]string // by host name
+}
+
+// Returns true if there are no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Returns changes one per line, like `+ host "web01"` or `~ item "agent.ping" on "web01" (delay)`.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+	var b strings.Builder
+	for i := range p.Changes {
+		b.WriteString(p.Changes[i].String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// NotOwnedError is returned by Reconciler.Plan when desired object exists but has no ownership tag.
+type NotOwnedError struct {
+	Object string
+	Name   string
+}
+
+func (e *NotOwnedError) Error() string {
+	return fmt.Sprintf("%s %q exists and is not managed by reconciler", e.Object, e.Name)
+}
+
+const DefaultOwnerTag = "managed-by"
+
+// Reconciler brings host groups, hosts and items to desired state.
+// Hosts it creates are tagged with OwnerTag equal to Owner, and only hosts with this tag are updated or deleted.
+// Items are tagged too on Zabbix 5.4+; earlier versions have no item tags, so the last line of item description
+// is set to ownership marker like "[managed-by: deploy]" instead. Items of owned hosts without tag or marker,
+// e.g. created by hand, are never updated or deleted. Host groups are created when missing, but never deleted.
+type Reconciler struct {
+	API      *API
+	Owner    string    // value of ownership tag, e.g. name of deployment tool
+	OwnerTag string    // name of ownership tag, DefaultOwnerTag if empty
+	DryRun   bool      // Apply prints plan instead of applying it
+	Out      io.Writer // output of dry-run, os.Stdout if nil
+}
+
+func NewReconciler(api *API, owner string) *Reconciler {
+	return &Reconciler{API: api, Owner: owner, OwnerTag: DefaultOwnerTag}
+}
+
+func (r *Reconciler) ownerTag() Tag {
+	name := r.OwnerTag
+	if name == "" {
+		name = DefaultOwnerTag
+	}
+	return Tag{Tag: name, Value: r.Owner}
+}
+
+func (r *Reconciler) owned(tags Tags) bool {
+	owner := r.ownerTag()
+	for _, t := range tags {
+		if t.Tag == owner.Tag && t.Value == owner.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// Computes changes required to bring server to desired state.
+func (r *Reconciler) Plan(desired DesiredState) (plan *Plan, err error) {
+	api := r.API
+	if err = api.require(FeatureHostTags); err != nil {
+		return
+	}
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	itemTags := v.Supports(FeatureItemTags)
+	if err = desired.validate(); err != nil {
+		return
+	}
+
+	plan = &Plan{groupIds: make(map[string]string), hostIds: make(map[string]string)}
+
+	// host groups
+	var groupNames []string
+	seen := make(map[string]bool)
+	for _, names := range append([][]string{desired.Groups}, desiredHostGroups(desired.Hosts)...) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				groupNames = append(groupNames, name)
+			}
+		}
+	}
+	if len(groupNames) > 0 {
+		groups, err := api.HostGroupsGet(Params{"filter": Params{"name": groupNames}})
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			plan.groupIds[g.Name] = g.GroupId
+		}
+	}
+	for _, name := range groupNames {
+		if _, ok := plan.groupIds[name]; !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeCreate, Object: "hostgroup", Name: name, group: name})
+		}
+	}
+
+	// hosts
+	selects := Params{"selectInterfaces": "extend", "selectTags": "extend", "selectHostGroups": "extend"}
+	owner := r.ownerTag()
+	ownedHosts, err := api.HostsGet(mergeParams(selects, Params{
+		"tags": []Params{{"tag": owner.Tag, "value": owner.Value, "operator": 1}},
+	}))
+	if err != nil {
+		return
+	}
+	current := make(map[string]*Host)
+	for i := range ownedHosts {
+		current[ownedHosts[i].Host] = &ownedHosts[i]
+	}
+	var names []string
+	for _, h := range desired.Hosts {
+		names = append(names, h.Host)
+	}
+	if len(names) > 0 {
+		hosts, err := api.HostsGet(mergeParams(selects, Params{"filter": Params{"host": names}}))
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			if !r.owned(h.Tags) {
+				return nil, &NotOwnedError{Object: "host", Name: h.Host}
+			}
+		}
+	}
+
+	var hostChanges, itemDeletes, itemChanges []Change
+	var existingIds []string
+	for i := range desired.Hosts {
+		d := &desired.Hosts[i]
+		h, ok := current[d.Host]
+		if !ok {
+			hostChanges = append(hostChanges, Change{Kind: ChangeCreate, Object: "host", Name: d.Host, host: d})
+			for j := range d.Items {
+				itemChanges = append(itemChanges, Change{Kind: ChangeCreate, Object: "item", Name: d.Items[j].Key, Host: d.Host, host: d, item: &d.Items[j]})
+			}
+			continue
+		}
+		plan.hostIds[d.Host] = h.HostId
+		existingIds = append(existingIds, h.HostId)
+		if fields := r.hostDiff(d, h); len(fields) > 0 {
+			hostChanges = append(hostChanges, Change{Kind: ChangeUpdate, Object: "host", Name: d.Host, Fields: fields, id: h.HostId, host: d})
+		}
+	}
+
+	// items of existing hosts
+	if len(existingIds) > 0 {
+		params := Params{"hostids": existingIds}
+		if itemTags {
+			params["selectTags"] = "extend"
+		}
+		items, err := api.ItemsGet(params)
+		if err != nil {
+			return nil, err
+		}
+		byHost := make(map[string]map[string]*Item)
+		for i := range items {
+			it := &items[i]
+			if byHost[it.HostId] == nil {
+				byHost[it.HostId] = make(map[string]*Item)
+			}
+			byHost[it.HostId][it.Key] = it
+		}
+
+		for i := range desired.Hosts {
+			d := &desired.Hosts[i]
+			hostId, ok := plan.hostIds[d.Host]
+			if !ok {
+				continue
+			}
+			desiredKeys := make(map[string]bool)
+			for j := range d.Items {
+				di := &d.Items[j]
+				desiredKeys[di.Key] = true
+				it, ok := byHost[hostId][di.Key]
+				if !ok {
+					itemChanges = append(itemChanges, Change{Kind: ChangeCreate, Object: "item", Name: di.Key, Host: d.Host, host: d, item: di})
+					continue
+				}
+				if !r.itemOwned(it, itemTags) {
+					return nil, &NotOwnedError{Object: "item", Name: d.Host + ":" + di.Key}
+				}
+				if fields := r.itemDiff(di, it, itemTags); len(fields) > 0 {
+					itemChanges = append(itemChanges, Change{Kind: ChangeUpdate, Object: "item", Name: di.Key, Host: d.Host, Fields: fields, id: it.ItemId, host: d, item: di})
+				}
+			}
+
+			var keys []string
+			for key, it := range byHost[hostId] {
+				if !desiredKeys[key] && r.itemOwned(it, itemTags) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				itemDeletes = append(itemDeletes, Change{Kind: ChangeDelete, Object: "item", Name: key, Host: d.Host, id: byHost[hostId][key].ItemId})
+			}
+		}
+	}
+
+	// owned hosts not in desired state
+	desiredHosts := make(map[string]bool, len(names))
+	for _, name := range names {
+		desiredHosts[name] = true
+	}
+	var hostDeletes []Change
+	for _, h := range ownedHosts {
+		if !desiredHosts[h.Host] {
+			hostDeletes = append(hostDeletes, Change{Kind: ChangeDelete, Object: "host", Name: h.Host, id: h.HostId})
+		}
+	}
+	sort.Slice(hostDeletes, func(i, j int) bool { return hostDeletes[i].Name < hostDeletes[j].Name })
+
+	plan.Changes = append(plan.Changes, itemDeletes...)
+	plan.Changes = append(plan.Changes, hostChanges...)
+	plan.Changes = append(plan.Changes, itemChanges...)
+	plan.Changes = append(plan.Changes, hostDeletes...)
+	return
+}
+
+// Applies plan computed by Plan, or prints it to Out if DryRun is set.
+func (r *Reconciler) Apply(plan *Plan) (err error) {
+	if r.DryRun {
+		out := r.Out
+		if out == nil {
+			out = os.Stdout
+		}
+		_, err = io.WriteString(out, plan.String())
+		return
+	}
+
+	api := r.API
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	itemTags := v.Supports(FeatureItemTags)
+	interfaces := make(map[string]HostInterfaces) // by host Id
+
+	for i := range plan.Changes {
+		c := &plan.Changes[i]
+		switch {
+		case c.Object == "hostgroup" && c.Kind == ChangeCreate:
+			groups := HostGroups{{Name: c.group}}
+			if err = api.HostGroupsCreate(groups); err == nil {
+				plan.groupIds[c.group] = groups[0].GroupId
+			}
+
+		case c.Object == "host" && c.Kind == ChangeCreate:
+			hosts := Hosts{r.host(c.host, plan, nil)}
+			if err = api.HostsCreate(hosts); err == nil {
+				plan.hostIds[c.Name] = hosts[0].HostId
+			}
+
+		case c.Object == "host" && c.Kind == ChangeUpdate:
+			var current HostInterfaces
+			if current, err = api.HostInterfacesGet(Params{"hostids": c.id}); err != nil {
+				break
+			}
+			h := r.host(c.host, plan, current)
+			h.HostId = c.id
+			update := Host{HostId: c.id}
+			copyFields(&update, &h, c.Fields)
+			err = api.HostsUpdate(Hosts{update}, c.Fields...)
+			delete(interfaces, c.id)
+
+		case c.Object == "host" && c.Kind == ChangeDelete:
+			err = api.HostsDeleteByIds([]string{c.id})
+
+		case c.Object == "item" && c.Kind == ChangeDelete:
+			err = api.ItemsDeleteByIds([]string{c.id})
+
+		case c.Object == "item":
+			hostId := plan.hostIds[c.Host]
+			if _, ok := interfaces[hostId]; !ok {
+				if interfaces[hostId], err = api.HostInterfacesGet(Params{"hostids": hostId}); err != nil {
+					break
+				}
+			}
+			it := r.item(c.item, itemTags)
+			it.HostId = hostId
+			it.InterfaceId = itemInterfaceId(it.Type, interfaces[hostId])
+			if c.Kind == ChangeCreate {
+				err = api.ItemsCreate(Items{it})
+				break
+			}
+			update := Item{ItemId: c.id}
+			fields := c.Fields
+			if itemInterfaceType(it.Type) != 0 {
+				fields = append(fields[:len(fields):len(fields)], "interfaceid")
+			}
+			copyFields(&update, &it, fields)
+			err = api.ItemsUpdate(Items{update}, fields...)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", c, err)
+		}
+	}
+	return
+}
+
+// Computes and applies plan. Plan is returned even if apply fails.
+func (r *Reconciler) Reconcile(desired DesiredState) (plan *Plan, err error) {
+	if plan, err = r.Plan(desired); err != nil {
+		return
+	}
+	err = r.Apply(plan)
+	return
+}
+
+func (d *DesiredState) validate() error {
+	hosts := make(map[string]bool)
+	for _, h := range d.Hosts {
+		if h.Host == "" {
+			return fmt.Errorf("host without name in desired state")
+		}
+		if hosts[h.Host] {
+			return fmt.Errorf("duplicate host %q in desired state", h.Host)
+		}
+		hosts[h.Host] = true
+
+		keys := make(map[string]bool)
+		for _, it := range h.Items {
+			if keys[it.Key] {
+				return fmt.Errorf("duplicate item %q on host %q in desired state", it.Key, h.Host)
+			}
+			keys[it.Key] = true
+		}
+	}
+	return nil
+}
+
+func desiredHostGroups(hosts []DesiredHost) (res [][]string) {
+	for _, h := range hosts {
+		res = append(res, h.Groups)
+	}
+	return
+}
+
+func mergeParams(a, b Params) Params {
+	res := make(Params, len(a)+len(b))
+	for k, v := range a {
+		res[k] = v
+	}
+	for k, v := range b {
+		res[k] = v
+	}
+	return res
+}
+
+// Returns host object for desired host. Interface Ids are taken from current interfaces of the same type and order.
+func (r *Reconciler) host(d *DesiredHost, plan *Plan, current HostInterfaces) Host {
+	h := Host{Host: d.Host, Name: d.Name, Status: d.Status, Interfaces: desiredInterfaces(d.Interfaces)}
+	if h.Name == "" {
+		h.Name = d.Host
+	}
+	for _, name := range d.Groups {
+		h.GroupIds = append(h.GroupIds, HostGroupId{GroupId: plan.groupIds[name]})
+	}
+	h.Tags = r.tags(d.Tags)
+
+	byType := make(map[InterfaceType]HostInterfaces)
+	for _, i := range sortedInterfaces(current) {
+		byType[i.Type] = append(byType[i.Type], i)
+	}
+	for i := range h.Interfaces {
+		t := h.Interfaces[i].Type
+		if len(byType[t]) > 0 {
+			h.Interfaces[i].InterfaceId = byType[t][0].InterfaceId
+			byType[t] = byType[t][1:]
+		}
+	}
+	return h
+}
+
+// Returns desired tags with ownership tag.
+func (r *Reconciler) tags(tags Tags) Tags {
+	res := Tags{r.ownerTag()}
+	for _, t := range tags {
+		if t != res[0] {
+			res = append(res, Tag{Tag: t.Tag, Value: t.Value})
+		}
+	}
+	return res
+}
+
+var defaultInterfacePorts = map[InterfaceType]string{Agent: "10050", SNMP: "161", IPMI: "623", JMX: "12345"}
+
+func desiredInterfaces(desired []DesiredInterface) (res HostInterfaces) {
+	main := make(map[InterfaceType]bool)
+	for _, d := range desired {
+		i := HostInterface{Type: d.Type, IP: d.IP, DNS: d.DNS, Port: d.Port, Details: d.Details}
+		if i.Port == "" {
+			i.Port = defaultInterfacePorts[d.Type]
+		}
+		if d.IP != "" {
+			i.UseIP = 1
+		}
+		if !main[d.Type] {
+			main[d.Type] = true
+			i.Main = 1
+		}
+		res = append(res, i)
+	}
+	return
+}
+
+// Returns interfaces with main ones first, ordered by Id.
+func sortedInterfaces(interfaces HostInterfaces) HostInterfaces {
+	res := append(HostInterfaces{}, interfaces...)
+	sortByNumericId(res, func(i HostInterface) string { return i.InterfaceId })
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Main > res[j].Main })
+	return res
+}
+
+// Returns JSON names of host fields which differ from desired.
+func (r *Reconciler) hostDiff(d *DesiredHost, h *Host) (fields []string) {
+	name := d.Name
+	if name == "" {
+		name = d.Host
+	}
+	if h.Name != name {
+		fields = append(fields, "name")
+	}
+	if h.Status != d.Status {
+		fields = append(fields, "status")
+	}
+
+	var current []string
+	for _, g := range h.Groups {
+		current = append(current, g.Name)
+	}
+	if !sameStrings(current, d.Groups) {
+		fields = append(fields, "groups")
+	}
+
+	want := desiredInterfaces(d.Interfaces)
+	have := sortedInterfaces(h.Interfaces)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].Main > want[j].Main })
+	if len(want) != len(have) {
+		fields = append(fields, "interfaces")
+	} else {
+		for i := range want {
+			w, c := want[i], have[i]
+			if w.Type != c.Type || w.Main != c.Main || w.IP != c.IP || w.DNS != c.DNS || w.Port != c.Port || w.UseIP != c.UseIP ||
+				(w.Details != nil && !reflect.DeepEqual(w.Details, c.Details)) {
+				fields = append(fields, "interfaces")
+				break
+			}
+		}
+	}
+
+	if !sameTags(h.Tags, r.tags(d.Tags)) {
+		fields = append(fields, "tags")
+	}
+	return
+}
+
+func (r *Reconciler) itemOwned(it *Item, itemTags bool) bool {
+	if (it.TemplateId != "" && it.TemplateId != "0") || it.Flags != 0 {
+		return false
+	}
+	if itemTags && r.owned(it.Tags) {
+		return true
+	}
+	// items created before upgrade to 5.4 are marked in description
+	return strings.HasSuffix(it.Description, r.itemMarker())
+}
+
+// Returns ownership marker of items on Zabbix before 5.4.
+func (r *Reconciler) itemMarker() string {
+	owner := r.ownerTag()
+	return fmt.Sprintf("[%s: %s]", owner.Tag, owner.Value)
+}
+
+// Returns description of desired item, with ownership marker on its last line before Zabbix 5.4.
+func (r *Reconciler) itemDescription(d *DesiredItem, itemTags bool) string {
+	if itemTags {
+		return d.Description
+	}
+	if d.Description == "" {
+		return r.itemMarker()
+	}
+	return d.Description + "\n" + r.itemMarker()
+}
+
+// Returns item object for desired item.
+func (r *Reconciler) item(d *DesiredItem, itemTags bool) Item {
+	it := Item{
+		Key:         d.Key,
+		Name:        d.Name,
+		Type:        d.Type,
+		ValueType:   d.ValueType,
+		Delay:       d.Delay,
+		Units:       d.Units,
+		Description: r.itemDescription(d, itemTags),
+	}
+	if itemTags {
+		it.Tags = r.tags(d.Tags)
+	}
+	return it
+}
+
+// Returns JSON names of item fields which differ from desired.
+func (r *Reconciler) itemDiff(d *DesiredItem, it *Item, itemTags bool) (fields []string) {
+	if it.Name != d.Name {
+		fields = append(fields, "name")
+	}
+	if it.Type != d.Type {
+		fields = append(fields, "type")
+	}
+	if it.ValueType != d.ValueType {
+		fields = append(fields, "value_type")
+	}
+	if !sameDelay(it.Delay, d.Delay) {
+		fields = append(fields, "delay")
+	}
+	if it.Units != d.Units {
+		fields = append(fields, "units")
+	}
+	if it.Description != r.itemDescription(d, itemTags) {
+		fields = append(fields, "description")
+	}
+	if itemTags && !sameTags(it.Tags, r.tags(d.Tags)) {
+		fields = append(fields, "tags")
+	}
+	return
+}
+
+// Returns type of interface required by item type, or 0 if item doesn't use interface.
+func itemInterfaceType(t ItemType) InterfaceType {
+	switch t {
+	case ZabbixAgent:
+		return Agent
+	case SNMPv1Agent, SNMPv2Agent, SNMPv3Agent:
+		return SNMP
+	case IPMIAgent:
+		return IPMI
+	case JMXAgent:
+		return JMX
+	}
+	return 0
+}
+
+func itemInterfaceId(t ItemType, interfaces HostInterfaces) string {
+	it := itemInterfaceType(t)
+	if it == 0 {
+		return ""
+	}
+	for _, i := range interfaces {
+		if i.Type == it && i.Main == 1 {
+			return i.InterfaceId
+		}
+	}
+	return ""
+}
+
+// Copies fields listed by JSON name from src to dst, both pointers to the same struct type.
+func copyFields(dst, src interface{}, fields []string) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	t := d.Type()
+	for _, name := range fields {
+		for i := 0; i < t.NumField(); i++ {
+			if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == name {
+				d.Field(i).Set(s.Field(i))
+			}
+		}
+	}
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
+
+// Compares update intervals, Zabbix returns "0" for items without interval, e.g. trapper items.
+func sameDelay(a, b string) bool {
+	if a == "0" {
+		a = ""
+	}
+	if b == "0" {
+		b = ""
+	}
+	return a == b
+}
+
+// Compares tags ignoring order and Automatic flag.
+func sameTags(a, b Tags) bool {
+	s := func(tags Tags) []string {
+		res := make([]string, len(tags))
+		for i, t := range tags {
+			res[i] = t.Tag + "\x00" + t.Value
+		}
+		return res
+	}
+	return sameStrings(s(a), s(b))
+}
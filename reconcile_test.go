@@ -0,0 +1,258 @@
+package zabbix_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+var reconcileDesired = DesiredState{Hosts: []DesiredHost{
+	{
+		Host:       "web01",
+		Groups:     []string{"Linux"},
+		Interfaces: []DesiredInterface{{Type: Agent, IP: "10.0.0.1"}},
+		Items: []DesiredItem{
+			{Key: "agent.ping", Name: "Ping", Type: ZabbixAgent, ValueType: Unsigned, Delay: "1m"},
+			{Key: "system.uptime", Name: "Uptime", Type: ZabbixAgent, ValueType: Unsigned, Delay: "5m", Units: "uptime"},
+		},
+	},
+	{
+		Host:       "web02",
+		Groups:     []string{"Linux", "Web"},
+		Interfaces: []DesiredInterface{{Type: Agent, DNS: "web02.example.com"}},
+		Items:      []DesiredItem{{Key: "agent.ping", Name: "Ping", Type: ZabbixAgent, ValueType: Unsigned, Delay: "1m"}},
+	},
+}}
+
+func reconcileHandlers(t *testing.T, calls *[]string) map[string]fakeHandler {
+	owner := []map[string]string{{"tag": "managed-by", "value": "deploy"}}
+	web01 := map[string]interface{}{
+		"hostid": "10", "host": "web01", "name": "web01", "status": "0", "tags": owner,
+		"hostgroups": []map[string]string{{"groupid": "2", "name": "Linux"}},
+		"interfaces": []map[string]string{{"interfaceid": "1", "type": "1", "main": "1", "useip": "1", "ip": "10.0.0.1", "port": "10050"}},
+	}
+	old := map[string]interface{}{"hostid": "11", "host": "old01", "name": "old01", "status": "0", "tags": owner}
+	record := func(method string, result interface{}) fakeHandler {
+		return func(raw json.RawMessage) interface{} {
+			*calls = append(*calls, method+" "+string(raw))
+			return result
+		}
+	}
+
+	return map[string]fakeHandler{
+		"hostgroup.get": func(raw json.RawMessage) interface{} {
+			return []map[string]string{{"groupid": "2", "name": "Linux"}}
+		},
+		"host.get": func(raw json.RawMessage) interface{} {
+			if _, present := decodeParams(t, raw)["tags"]; present {
+				return []interface{}{web01, old}
+			}
+			return []interface{}{web01}
+		},
+		"item.get": func(raw json.RawMessage) interface{} {
+			return []map[string]interface{}{
+				{"itemid": "100", "hostid": "10", "key_": "agent.ping", "name": "Ping", "type": "0", "value_type": "3", "delay": "1m", "tags": owner},
+				{"itemid": "101", "hostid": "10", "key_": "system.uptime", "name": "Uptime", "type": "0", "value_type": "3", "delay": "1m", "tags": owner},
+				{"itemid": "102", "hostid": "10", "key_": "old.key", "name": "Old", "type": "0", "value_type": "3", "delay": "1m", "tags": owner},
+				{"itemid": "103", "hostid": "10", "key_": "icmpping", "name": "ICMP", "type": "3", "value_type": "3", "templateid": "50", "tags": owner},
+				{"itemid": "104", "hostid": "10", "key_": "manual", "name": "Manual", "type": "2", "value_type": "3"},
+			}
+		},
+		"hostinterface.get": func(raw json.RawMessage) interface{} {
+			hostId := decodeParams(t, raw)["hostids"]
+			return []map[string]interface{}{{"interfaceid": "1" + hostId.(string), "hostid": hostId, "type": "1", "main": "1"}}
+		},
+		"hostgroup.create": record("hostgroup.create", map[string]interface{}{"groupids": []string{"3"}}),
+		"host.create":      record("host.create", map[string]interface{}{"hostids": []string{"12"}}),
+		"host.delete":      record("host.delete", map[string]interface{}{"hostids": []string{"11"}}),
+		"item.create":      record("item.create", map[string]interface{}{"itemids": []string{"105"}}),
+		"item.update":      record("item.update", map[string]interface{}{"itemids": []string{"101"}}),
+		"item.delete":      record("item.delete", map[string]interface{}{"itemids": []string{"102"}}),
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	var calls []string
+	api := newFakeAPI(t, "6.4.0", reconcileHandlers(t, &calls))
+	r := NewReconciler(api, "deploy")
+
+	plan, err := r.Plan(reconcileDesired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `+ hostgroup "Web"
+- item "old.key" on "web01"
++ host "web02"
++ item "agent.ping" on "web02"
+~ item "system.uptime" on "web01" (delay, units)
+- host "old01"
+`
+	if plan.String() != expected {
+		t.Errorf("expected plan:\n%s\ngot:\n%s", expected, plan)
+	}
+
+	var out bytes.Buffer
+	r.DryRun, r.Out = true, &out
+	if err = r.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != expected || len(calls) != 0 {
+		t.Errorf("unexpected dry-run output %q and calls %v", out.String(), calls)
+	}
+
+	r.DryRun = false
+	if err = r.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range []string{
+		`hostgroup.create [{"name":"Web"}]`,
+		`item.delete ["102"]`,
+		`host.create [{"groups":[{"groupid":"2"},{"groupid":"3"}],"host":"web02","interfaces":[{"dns":"web02.example.com","hostid":"","interfaceid":"","ip":"","main":1,"port":"10050","type":1,"useip":0}],"name":"web02","status":0,"tags":[{"tag":"managed-by","value":"deploy"}]}]`,
+		`item.create [{"delay":"1m","description":"","hostid":"12","interfaceid":"112","key_":"agent.ping","name":"Ping","tags":[{"tag":"managed-by","value":"deploy"}],"type":0,"value_type":3}]`,
+		`item.update [{"delay":"5m","interfaceid":"110","itemid":"101","units":"uptime"}]`,
+		`host.delete ["11"]`,
+	} {
+		if i >= len(calls) || calls[i] != c {
+			t.Errorf("call %d: expected\n%s\ngot\n%v", i, c, calls)
+			break
+		}
+	}
+}
+
+func TestReconcilerNotOwned(t *testing.T) {
+	var calls []string
+	handlers := reconcileHandlers(t, &calls)
+	handlers["host.get"] = func(raw json.RawMessage) interface{} {
+		if _, present := decodeParams(t, raw)["tags"]; present {
+			return []interface{}{}
+		}
+		return []map[string]interface{}{{"hostid": "10", "host": "web01", "name": "web01"}}
+	}
+	api := newFakeAPI(t, "6.4.0", handlers)
+
+	_, err := NewReconciler(api, "deploy").Plan(reconcileDesired)
+	var e *NotOwnedError
+	if !errors.As(err, &e) || !reflect.DeepEqual(*e, NotOwnedError{Object: "host", Name: "web01"}) {
+		t.Errorf("expected NotOwnedError, got %v", err)
+	}
+}
+
+func TestReconcilerTrapperDelay(t *testing.T) {
+	var calls []string
+	handlers := reconcileHandlers(t, &calls)
+	owner := []map[string]string{{"tag": "managed-by", "value": "deploy"}}
+	handlers["item.get"] = func(raw json.RawMessage) interface{} {
+		return []map[string]interface{}{
+			{"itemid": "100", "hostid": "10", "key_": "agent.ping", "name": "Ping", "type": "0", "value_type": "3", "delay": "1m", "tags": owner},
+			{"itemid": "106", "hostid": "10", "key_": "app.value", "name": "Value", "type": "2", "value_type": "3", "delay": "0", "tags": owner},
+		}
+	}
+	api := newFakeAPI(t, "6.4.0", handlers)
+
+	desired := DesiredState{Hosts: []DesiredHost{reconcileDesired.Hosts[0]}}
+	desired.Hosts[0].Items = []DesiredItem{desired.Hosts[0].Items[0], {Key: "app.value", Name: "Value", Type: ZabbixTrapper, ValueType: Unsigned}}
+	plan, err := NewReconciler(api, "deploy").Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "- host \"old01\"\n"; plan.String() != expected {
+		t.Errorf("expected plan:\n%s\ngot:\n%s", expected, plan)
+	}
+}
+
+func TestReconcilerItemMarker(t *testing.T) {
+	var calls []string
+	handlers := reconcileHandlers(t, &calls)
+	handlers["item.get"] = func(raw json.RawMessage) interface{} {
+		if _, present := decodeParams(t, raw)["selectTags"]; present {
+			t.Errorf("unexpected params %s", raw)
+		}
+		return []map[string]interface{}{
+			{"itemid": "100", "hostid": "10", "key_": "agent.ping", "name": "Ping", "type": "0", "value_type": "3", "delay": "1m", "description": "[managed-by: deploy]"},
+			{"itemid": "101", "hostid": "10", "key_": "system.uptime", "name": "Uptime", "type": "0", "value_type": "3", "delay": "1m", "description": "Old\n[managed-by: deploy]"},
+			{"itemid": "102", "hostid": "10", "key_": "old.key", "name": "Old", "type": "0", "value_type": "3", "delay": "1m", "description": "[managed-by: deploy]"},
+			{"itemid": "104", "hostid": "10", "key_": "manual", "name": "Manual", "type": "2", "value_type": "3", "description": "by hand"},
+		}
+	}
+	api := newFakeAPI(t, "5.0.0", handlers)
+	r := NewReconciler(api, "deploy")
+
+	plan, err := r.Plan(reconcileDesired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `+ hostgroup "Web"
+- item "old.key" on "web01"
++ host "web02"
++ item "agent.ping" on "web02"
+~ item "system.uptime" on "web01" (delay, units, description)
+- host "old01"
+`
+	if plan.String() != expected {
+		t.Errorf("expected plan:\n%s\ngot:\n%s", expected, plan)
+	}
+	if err = r.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{
+		`item.create [{"delay":"1m","description":"[managed-by: deploy]","hostid":"12","interfaceid":"112","key_":"agent.ping","name":"Ping","type":0,"value_type":3}]`,
+		`item.update [{"delay":"5m","description":"[managed-by: deploy]","interfaceid":"110","itemid":"101","units":"uptime"}]`,
+	} {
+		found := false
+		for _, call := range calls {
+			found = found || call == c
+		}
+		if !found {
+			t.Errorf("expected call\n%s\ngot\n%v", c, calls)
+		}
+	}
+
+	// desired item created by hand is not taken over
+	desired := DesiredState{Hosts: []DesiredHost{reconcileDesired.Hosts[0]}}
+	desired.Hosts[0].Items = append(desired.Hosts[0].Items[:2:2], DesiredItem{Key: "manual", Name: "Manual", Type: ZabbixTrapper, ValueType: Unsigned})
+	var e *NotOwnedError
+	if _, err = r.Plan(desired); !errors.As(err, &e) || e.Name != "web01:manual" {
+		t.Errorf("expected NotOwnedError, got %v", err)
+	}
+}
+
+func TestLoadDesiredState(t *testing.T) {
+	yaml := `
+hosts:
+  - host: web01
+    groups: [Linux]
+    interfaces:
+      - {type: 1, ip: 10.0.0.1}
+    items:
+      - {key: agent.ping, name: Ping, type: 0, value_type: 3, delay: 1m}
+      - {key: system.uptime, name: Uptime, type: 0, value_type: 3, delay: 5m, units: uptime}
+  - host: web02
+    groups: [Linux, Web]
+    interfaces:
+      - {type: 1, dns: web02.example.com}
+    items:
+      - {key: agent.ping, name: Ping, type: 0, value_type: 3, delay: 1m}
+`
+	b, err := json.Marshal(reconcileDesired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, source := range []string{yaml, string(b)} {
+		desired, err := LoadDesiredState(strings.NewReader(source))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*desired, reconcileDesired) {
+			t.Errorf("unexpected desired state %+v", desired)
+		}
+	}
+
+	if _, err = LoadDesiredState(strings.NewReader("hosts:\n  - hots: web01\n")); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
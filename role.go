@@ -0,0 +1,93 @@
+package zabbix
+
+type (
+	RuleStatus int
+	APIMode    int
+)
+
+const (
+	RuleDisabled RuleStatus = 0
+	RuleEnabled  RuleStatus = 1
+)
+
+const (
+	APIDenyList  APIMode = 0
+	APIAllowList APIMode = 1
+)
+
+// Access to UI element or action, like "monitoring.hosts" or "edit_dashboards".
+type RoleRule struct {
+	Name   string     `json:"name"`
+	Status RuleStatus `json:"status"`
+}
+
+type RoleRuleList []RoleRule
+
+// Access to frontend module.
+type ModuleRule struct {
+	ModuleId string     `json:"moduleid"`
+	Status   RuleStatus `json:"status"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/role/object#role-rules
+// Nil default access fields are not sent, so Zabbix default RuleEnabled applies on create.
+type RoleRules struct {
+	UI                   RoleRuleList `json:"ui,omitempty"`
+	UIDefaultAccess      *RuleStatus  `json:"ui.default_access,omitempty"`
+	Modules              []ModuleRule `json:"modules,omitempty"`
+	ModulesDefaultAccess *RuleStatus  `json:"modules.default_access,omitempty"`
+	APIAccess            *RuleStatus  `json:"api.access,omitempty"`
+	APIMode              APIMode      `json:"api.mode"`
+	API                  []string     `json:"api,omitempty"` // methods like "host.get" or "*.delete"
+	Actions              RoleRuleList `json:"actions,omitempty"`
+	ActionsDefaultAccess *RuleStatus  `json:"actions.default_access,omitempty"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/role/object
+type Role struct {
+	RoleId   string     `json:"roleid,omitempty"`
+	Name     string     `json:"name"`
+	Type     UserType   `json:"type"`
+	ReadOnly int        `json:"readonly,omitempty" zabbix:"readonly"` // 1 for built-in roles
+	Rules    *RoleRules `json:"rules,omitempty"`                      // use "selectRules" to get them
+}
+
+type Roles []Role
+
+func (r *Role) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "role", IdField: "roleid", Requires: FeatureRoles}
+}
+func (r *Role) ObjectId() string      { return r.RoleId }
+func (r *Role) SetObjectId(id string) { r.RoleId = id }
+
+// Wrapper for role.get: https://www.zabbix.com/documentation/current/manual/api/reference/role/get
+func (api *API) RolesGet(params Params) (res Roles, err error) {
+	return GetObjects[Role](api, params)
+}
+
+// Gets role by Id only if there is exactly 1 matching role. Rules are selected.
+func (api *API) RoleGetById(id string) (res *Role, err error) {
+	return GetObject[Role](api, Params{"roleids": id, "selectRules": "extend"})
+}
+
+// Wrapper for role.create: https://www.zabbix.com/documentation/current/manual/api/reference/role/create
+func (api *API) RolesCreate(roles Roles) (err error) {
+	return CreateObjects[Role](api, roles)
+}
+
+// Wrapper for role.update: https://www.zabbix.com/documentation/current/manual/api/reference/role/update
+// Non-nil Rules replace existing ones.
+func (api *API) RolesUpdate(roles Roles, fields ...string) (err error) {
+	return UpdateObjects[Role](api, roles, fields...)
+}
+
+// Wrapper for role.delete: https://www.zabbix.com/documentation/current/manual/api/reference/role/delete
+// Cleans RoleId in all roles elements if call succeed.
+func (api *API) RolesDelete(roles Roles) (err error) {
+	return DeleteObjects[Role](api, roles)
+}
+
+// Wrapper for role.delete: https://www.zabbix.com/documentation/current/manual/api/reference/role/delete
+func (api *API) RolesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Role](api, ids)
+}
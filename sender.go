@@ -2,6 +2,7 @@ package zabbix
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,7 @@ type SenderData struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 	Clock int64  `json:"clock,omitempty"` // Unix timestamp, 0 means current time
+	Ns    int64  `json:"ns,omitempty"`    // Nanoseconds of Clock
 }
 
 // SenderResponse represents the response from Zabbix Server
@@ -27,10 +29,11 @@ type SenderResponse struct {
 
 // Sender provides functionality to send data to Zabbix Server using Zabbix Sender Protocol
 type Sender struct {
-	Server  string        // Zabbix Server address (host:port)
-	Port    int           // Zabbix Server port (default: 10051)
-	Timeout time.Duration // Connection timeout (default: 5 seconds)
-	Logger  *log.Logger   // Logger for debugging
+	Server    string        // Zabbix Server address (host:port)
+	Port      int           // Zabbix Server port (default: 10051)
+	Timeout   time.Duration // Connection timeout (default: 5 seconds)
+	TLSConfig *tls.Config   // certificate-based connection, nil for unencrypted
+	Logger    *log.Logger   // Logger for debugging
 }
 
 // NewSender creates a new Sender instance
@@ -89,7 +92,13 @@ func (s *Sender) SendBatch(data []SenderData) (*SenderResponse, error) {
 
 	// Connect to Zabbix Server
 	address := net.JoinHostPort(s.Server, fmt.Sprintf("%d", s.Port))
-	conn, err := net.DialTimeout("tcp", address, s.Timeout)
+	dialer := &net.Dialer{Timeout: s.Timeout}
+	var conn net.Conn
+	if s.TLSConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, s.TLSConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
 	}
@@ -154,14 +163,6 @@ func (s *Sender) SendBatch(data []SenderData) (*SenderResponse, error) {
 // BuildPacket builds a ZBXD protocol packet
 // Format: "ZBXD\1" + 8 bytes (little-endian data length) + JSON data
 func (s *Sender) BuildPacket(data []byte) []byte {
-	header := []byte("ZBXD\x01")
-	length := make([]byte, 8)
-	binary.LittleEndian.PutUint64(length, uint64(len(data)))
-
-	packet := make([]byte, 0, len(header)+len(length)+len(data))
-	packet = append(packet, header...)
-	packet = append(packet, length...)
-	packet = append(packet, data...)
-
+	packet, _ := buildPacket(data, false) // fails only with compression
 	return packet
 }
@@ -0,0 +1,120 @@
+package zabbix
+
+type (
+	ServiceAlgorithm int
+	PropagationRule  int
+	StatusRuleType   int
+)
+
+// Status of service: ServiceOK or trigger severity 0-5 of the most critical problem.
+const ServiceOK = -1
+
+const (
+	ServiceSetOK           ServiceAlgorithm = 0
+	ServiceMostCriticalAll ServiceAlgorithm = 1 // if all children have problems
+	ServiceMostCriticalOne ServiceAlgorithm = 2 // of child services
+)
+
+const (
+	PropagateAsIs     PropagationRule = 0
+	PropagateIncrease PropagationRule = 1 // by PropagationValue levels
+	PropagateDecrease PropagationRule = 2 // by PropagationValue levels
+	PropagateIgnore   PropagationRule = 3
+	PropagateFixed    PropagationRule = 4 // PropagationValue status
+)
+
+// Condition of status rule on child services, N is LimitValue and status is LimitStatus.
+const (
+	StatusRuleAtLeastCount         StatusRuleType = 0 // at least N children have status or above
+	StatusRuleAtLeastPercent       StatusRuleType = 1 // at least N% of children have status or above
+	StatusRuleLessCount            StatusRuleType = 2 // less than N children have status or below
+	StatusRuleLessPercent          StatusRuleType = 3 // less than N% of children have status or below
+	StatusRuleAtLeastWeight        StatusRuleType = 4 // weight of children with status or above is at least N
+	StatusRuleAtLeastWeightPercent StatusRuleType = 5 // weight of children with status or above is at least N%
+	StatusRuleLessWeight           StatusRuleType = 6 // weight of children with status or below is less than N
+	StatusRuleLessWeightPercent    StatusRuleType = 7 // weight of children with status or below is less than N%
+)
+
+// Rule setting service status to NewStatus when condition on child services is met.
+type ServiceStatusRule struct {
+	Type        StatusRuleType `json:"type"`
+	LimitValue  int            `json:"limit_value"`
+	LimitStatus int            `json:"limit_status"` // ServiceOK or severity
+	NewStatus   int            `json:"new_status"`   // severity
+}
+
+type ServiceId struct {
+	ServiceId string `json:"serviceid"`
+}
+
+type ServiceIds []ServiceId
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/service/object (Zabbix 6.0+)
+type Service struct {
+	ServiceId        string           `json:"serviceid,omitempty"`
+	Name             string           `json:"name"`
+	Algorithm        ServiceAlgorithm `json:"algorithm"`
+	SortOrder        int              `json:"sortorder"`
+	Weight           int              `json:"weight,omitempty"`
+	PropagationRule  PropagationRule  `json:"propagation_rule,omitempty"`
+	PropagationValue int              `json:"propagation_value,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	Status           int              `json:"status,omitempty" zabbix:"readonly"` // ServiceOK or severity
+	CreatedAt        int64            `json:"created_at,omitempty" zabbix:"readonly"`
+	ReadOnly         bool             `json:"readonly,omitempty" zabbix:"readonly"` // true if current user can't update service
+
+	// Use "selectParents", "selectChildren", "selectTags", "selectProblemTags" and "selectStatusRules" to get them.
+	// Services with ProblemTags can't have children.
+	Parents     ServiceIds          `json:"parents,omitempty"`
+	Children    ServiceIds          `json:"children,omitempty"`
+	Tags        Tags                `json:"tags,omitempty"`
+	ProblemTags ProblemTags         `json:"problem_tags,omitempty"`
+	StatusRules []ServiceStatusRule `json:"status_rules,omitempty"`
+}
+
+type Services []Service
+
+func (s *Service) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "service", IdField: "serviceid", Requires: FeatureSLA}
+}
+func (s *Service) ObjectId() string      { return s.ServiceId }
+func (s *Service) SetObjectId(id string) { s.ServiceId = id }
+
+// Wrapper for service.get: https://www.zabbix.com/documentation/current/manual/api/reference/service/get
+func (api *API) ServicesGet(params Params) (res Services, err error) {
+	return GetObjects[Service](api, params)
+}
+
+// Gets service with relations, tags and status rules by Id only if there is exactly 1 matching service.
+func (api *API) ServiceGetById(id string) (res *Service, err error) {
+	return GetObject[Service](api, Params{
+		"serviceids":        id,
+		"selectParents":     []string{"serviceid"},
+		"selectChildren":    []string{"serviceid"},
+		"selectTags":        "extend",
+		"selectProblemTags": "extend",
+		"selectStatusRules": "extend",
+	})
+}
+
+// Wrapper for service.create: https://www.zabbix.com/documentation/current/manual/api/reference/service/create
+func (api *API) ServicesCreate(services Services) (err error) {
+	return CreateObjects[Service](api, services)
+}
+
+// Wrapper for service.update: https://www.zabbix.com/documentation/current/manual/api/reference/service/update
+// Non-empty Parents, Children, Tags, ProblemTags and StatusRules replace existing ones.
+func (api *API) ServicesUpdate(services Services, fields ...string) (err error) {
+	return UpdateObjects[Service](api, services, fields...)
+}
+
+// Wrapper for service.delete: https://www.zabbix.com/documentation/current/manual/api/reference/service/delete
+// Cleans ServiceId in all services elements if call succeed.
+func (api *API) ServicesDelete(services Services) (err error) {
+	return DeleteObjects[Service](api, services)
+}
+
+// Wrapper for service.delete: https://www.zabbix.com/documentation/current/manual/api/reference/service/delete
+func (api *API) ServicesDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Service](api, ids)
+}
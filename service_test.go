@@ -0,0 +1,66 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestServiceCreate(t *testing.T) {
+	expected := `[{"algorithm":2,"name":"Checkout","parents":[{"serviceid":"1"}],` +
+		`"problem_tags":[{"operator":0,"tag":"service","value":"checkout"}],"sortorder":0,` +
+		`"status_rules":[{"limit_status":3,"limit_value":50,"new_status":4,"type":1}],"tags":[{"tag":"team","value":"shop"}]}]`
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"service.create": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"serviceids": []string{"2"}}
+		},
+	})
+	services := Services{{
+		Name: "Checkout", Algorithm: ServiceMostCriticalOne, Status: 4,
+		Parents:     ServiceIds{{ServiceId: "1"}},
+		Tags:        Tags{{Tag: "team", Value: "shop"}},
+		ProblemTags: ProblemTags{{Tag: "service", Operator: TagEquals, Value: "checkout"}},
+		StatusRules: []ServiceStatusRule{{Type: StatusRuleAtLeastPercent, LimitValue: 50, LimitStatus: 3, NewStatus: 4}},
+	}}
+	if err := api.ServicesCreate(services); err != nil {
+		t.Fatal(err)
+	}
+	if services[0].ServiceId != "2" {
+		t.Errorf("unexpected Id %q", services[0].ServiceId)
+	}
+}
+
+func TestServiceDecode(t *testing.T) {
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"service.get": func(raw json.RawMessage) interface{} {
+			return json.RawMessage(`[{"serviceid":"2","name":"Checkout","status":"-1","algorithm":"2","sortorder":"0","weight":"0",
+				"propagation_rule":"0","propagation_value":"0","readonly":true,"created_at":"1700000000",
+				"parents":[{"serviceid":"1"}],"children":[],"tags":[],"problem_tags":[{"tag":"service","operator":"2","value":"check"}],"status_rules":[]}]`)
+		},
+	})
+	service, err := api.ServiceGetById("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Status != ServiceOK || !service.ReadOnly || service.CreatedAt != 1700000000 ||
+		!reflect.DeepEqual(service.Parents, ServiceIds{{ServiceId: "1"}}) ||
+		!reflect.DeepEqual(service.ProblemTags, ProblemTags{{Tag: "service", Operator: TagContains, Value: "check"}}) {
+		t.Errorf("unexpected service %+v", service)
+	}
+}
+
+func TestServiceUnsupported(t *testing.T) {
+	api := newFakeAPI(t, "5.0.0", nil)
+	if _, err := api.ServicesGet(Params{}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+	if _, err := api.SLAsGet(Params{}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+}
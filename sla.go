@@ -0,0 +1,203 @@
+package zabbix
+
+import "time"
+
+type (
+	SLAPeriod int
+	SLAStatus int
+)
+
+const (
+	SLADaily     SLAPeriod = 0
+	SLAWeekly    SLAPeriod = 1
+	SLAMonthly   SLAPeriod = 2
+	SLAQuarterly SLAPeriod = 3
+	SLAAnnually  SLAPeriod = 4
+)
+
+const (
+	SLADisabled SLAStatus = 0
+	SLAEnabled  SLAStatus = 1
+)
+
+// Weekly period of SLA schedule, in seconds since Sunday 00:00.
+type SLASchedule struct {
+	PeriodFrom int64 `json:"period_from"`
+	PeriodTo   int64 `json:"period_to"`
+}
+
+// Returns schedule period on given day (0 - Sunday) between from and to (time since midnight).
+func SLAScheduleDay(day time.Weekday, from, to time.Duration) SLASchedule {
+	start := int64(day) * 24 * 3600
+	return SLASchedule{PeriodFrom: start + int64(from/time.Second), PeriodTo: start + int64(to/time.Second)}
+}
+
+// Period excluded from SLA calculation, Unix time.
+type SLAExcludedDowntime struct {
+	Name       string `json:"name"`
+	PeriodFrom int64  `json:"period_from"`
+	PeriodTo   int64  `json:"period_to"`
+}
+
+// Tag of services covered by SLA, only TagEquals and TagContains operators are supported.
+type SLAServiceTag struct {
+	Tag      string      `json:"tag"`
+	Operator TagOperator `json:"operator"`
+	Value    string      `json:"value,omitempty"`
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/sla/object (Zabbix 6.0+)
+type SLA struct {
+	SLAId         string    `json:"slaid,omitempty"`
+	Name          string    `json:"name"`
+	Period        SLAPeriod `json:"period"`
+	SLO           float64   `json:"slo"`                      // percentage, e.g. 99.9
+	EffectiveDate int64     `json:"effective_date,omitempty"` // Unix time
+	Timezone      string    `json:"timezone,omitempty"`       // e.g. "Europe/Riga", default is "UTC"
+	Status        SLAStatus `json:"status,omitempty"`         // SLAEnabled if zero on create; list "status" in SLAsUpdate fields to disable
+	Description   string    `json:"description,omitempty"`
+
+	// Use "selectSchedule", "selectExcludedDowntimes" and "selectServiceTags" to get them.
+	// Empty Schedule means 24x7.
+	Schedule          []SLASchedule         `json:"schedule,omitempty"`
+	ExcludedDowntimes []SLAExcludedDowntime `json:"excluded_downtimes,omitempty"`
+	ServiceTags       []SLAServiceTag       `json:"service_tags,omitempty"`
+}
+
+type SLAs []SLA
+
+func (s *SLA) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "sla", IdField: "slaid", Requires: FeatureSLA}
+}
+func (s *SLA) ObjectId() string      { return s.SLAId }
+func (s *SLA) SetObjectId(id string) { s.SLAId = id }
+
+// Wrapper for sla.get: https://www.zabbix.com/documentation/current/manual/api/reference/sla/get
+func (api *API) SLAsGet(params Params) (res SLAs, err error) {
+	return GetObjects[SLA](api, params)
+}
+
+// Gets SLA with schedule, excluded downtimes and service tags by Id only if there is exactly 1 matching SLA.
+func (api *API) SLAGetById(id string) (res *SLA, err error) {
+	return GetObject[SLA](api, Params{
+		"slaids":                  id,
+		"selectSchedule":          "extend",
+		"selectExcludedDowntimes": "extend",
+		"selectServiceTags":       "extend",
+	})
+}
+
+// Wrapper for sla.create: https://www.zabbix.com/documentation/current/manual/api/reference/sla/create
+func (api *API) SLAsCreate(slas SLAs) (err error) {
+	return CreateObjects[SLA](api, slas)
+}
+
+// Wrapper for sla.update: https://www.zabbix.com/documentation/current/manual/api/reference/sla/update
+// Non-empty Schedule, ExcludedDowntimes and ServiceTags replace existing ones.
+func (api *API) SLAsUpdate(slas SLAs, fields ...string) (err error) {
+	return UpdateObjects[SLA](api, slas, fields...)
+}
+
+// Wrapper for sla.delete: https://www.zabbix.com/documentation/current/manual/api/reference/sla/delete
+// Cleans SLAId in all SLAs elements if call succeed.
+func (api *API) SLAsDelete(slas SLAs) (err error) {
+	return DeleteObjects[SLA](api, slas)
+}
+
+// Wrapper for sla.delete: https://www.zabbix.com/documentation/current/manual/api/reference/sla/delete
+func (api *API) SLAsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[SLA](api, ids)
+}
+
+// SLI of service for reporting period.
+type SLIValue struct {
+	Uptime            time.Duration
+	Downtime          time.Duration
+	ErrorBudget       time.Duration // negative if SLO is not met
+	SLI               float64       // percentage of uptime
+	ExcludedDowntimes []SLAExcludedDowntime
+}
+
+// Reporting period of SLI.
+type SLIPeriod struct {
+	From, To time.Time
+}
+
+// Result of SLAGetSLI: SLI[i][j] is SLI of ServiceIds[j] for Periods[i].
+type SLIReport struct {
+	Periods    []SLIPeriod
+	ServiceIds []string
+	SLI        [][]SLIValue
+}
+
+// Returns SLI values of service for all periods, or nil if service is not in report.
+func (r *SLIReport) Service(id string) (res []SLIValue) {
+	for j, s := range r.ServiceIds {
+		if s != id {
+			continue
+		}
+		res = make([]SLIValue, len(r.Periods))
+		for i := range r.Periods {
+			if i < len(r.SLI) && j < len(r.SLI[i]) {
+				res[i] = r.SLI[i][j]
+			}
+		}
+		return
+	}
+	return
+}
+
+// Wrapper for sla.getsli: https://www.zabbix.com/documentation/current/manual/api/reference/sla/getsli
+// Zero from and to, zero number of periods and empty serviceIds use Zabbix defaults:
+// last 20 periods for all services of SLA.
+func (api *API) SLAGetSLI(slaId string, from, to time.Time, periods int, serviceIds []string) (res *SLIReport, err error) {
+	if err = api.require(FeatureSLA); err != nil {
+		return
+	}
+	params := Params{"slaid": slaId}
+	if !from.IsZero() {
+		params["period_from"] = from.Unix()
+	}
+	if !to.IsZero() {
+		params["period_to"] = to.Unix()
+	}
+	if periods > 0 {
+		params["periods"] = periods
+	}
+	if len(serviceIds) > 0 {
+		params["serviceids"] = serviceIds
+	}
+
+	var raw struct {
+		Periods    []SLASchedule `json:"periods"`
+		ServiceIds []string      `json:"serviceids"`
+		SLI        [][]struct {
+			Uptime            int64                 `json:"uptime"`
+			Downtime          int64                 `json:"downtime"`
+			SLI               float64               `json:"sli"`
+			ErrorBudget       int64                 `json:"error_budget"`
+			ExcludedDowntimes []SLAExcludedDowntime `json:"excluded_downtimes"`
+		} `json:"sli"`
+	}
+	if err = api.callResult("sla.getsli", params, &raw); err != nil {
+		return
+	}
+
+	res = &SLIReport{ServiceIds: raw.ServiceIds, Periods: make([]SLIPeriod, len(raw.Periods)), SLI: make([][]SLIValue, len(raw.SLI))}
+	for i, p := range raw.Periods {
+		res.Periods[i] = SLIPeriod{From: time.Unix(p.PeriodFrom, 0), To: time.Unix(p.PeriodTo, 0)}
+	}
+	for i, values := range raw.SLI {
+		res.SLI[i] = make([]SLIValue, len(values))
+		for j, v := range values {
+			res.SLI[i][j] = SLIValue{
+				Uptime:            time.Duration(v.Uptime) * time.Second,
+				Downtime:          time.Duration(v.Downtime) * time.Second,
+				ErrorBudget:       time.Duration(v.ErrorBudget) * time.Second,
+				SLI:               v.SLI,
+				ExcludedDowntimes: v.ExcludedDowntimes,
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestSLACreate(t *testing.T) {
+	expected := `[{"effective_date":1700000000,"name":"Shop","period":2,"schedule":[{"period_from":97200,"period_to":147600}],` +
+		`"service_tags":[{"operator":0,"tag":"team","value":"shop"}],"slo":99.9,"status":1}]`
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"sla.create": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"slaids": []string{"4"}}
+		},
+	})
+	slas := SLAs{{
+		Name: "Shop", Period: SLAMonthly, SLO: 99.9, EffectiveDate: 1700000000, Status: SLAEnabled,
+		Schedule:    []SLASchedule{SLAScheduleDay(time.Monday, 3*time.Hour, 17*time.Hour)},
+		ServiceTags: []SLAServiceTag{{Tag: "team", Operator: TagEquals, Value: "shop"}},
+	}}
+	if err := api.SLAsCreate(slas); err != nil {
+		t.Fatal(err)
+	}
+	if slas[0].SLAId != "4" {
+		t.Errorf("unexpected Id %q", slas[0].SLAId)
+	}
+}
+
+func TestSLAStatus(t *testing.T) {
+	var expected string
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"sla.create": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"slaids": []string{"4"}}
+		},
+		"sla.update": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"slaids": []string{"4"}}
+		},
+	})
+
+	expected = `[{"name":"Shop","period":0,"slo":99}]`
+	slas := SLAs{{Name: "Shop", SLO: 99}}
+	if err := api.SLAsCreate(slas); err != nil {
+		t.Fatal(err)
+	}
+	expected = `[{"slaid":"4","status":0}]`
+	if err := api.SLAsUpdate(SLAs{{SLAId: "4", Status: SLADisabled}}, "status"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSLAGetSLI(t *testing.T) {
+	from := time.Unix(1698796800, 0)
+	api := newFakeAPI(t, "7.0.0", map[string]fakeHandler{
+		"sla.getsli": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["slaid"] != "4" || p["period_from"] != float64(from.Unix()) || p["periods"] != 2.0 || p["period_to"] != nil {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return json.RawMessage(`{
+				"periods":[{"period_from":1698796800,"period_to":1701388800},{"period_from":1701388800,"period_to":1704067200}],
+				"serviceids":[2,3],
+				"sli":[
+					[{"uptime":2592000,"downtime":0,"sli":100,"error_budget":2592,"excluded_downtimes":[]},
+					 {"uptime":2588400,"downtime":3600,"sli":99.86,"error_budget":-1008,"excluded_downtimes":[]}],
+					[{"uptime":1000,"downtime":0,"sli":100,"error_budget":1,
+					  "excluded_downtimes":[{"name":"Upgrade","period_from":1701400000,"period_to":1701403600}]},
+					 {"uptime":1000,"downtime":0,"sli":100,"error_budget":1,"excluded_downtimes":[]}]
+				]}`)
+		},
+	})
+
+	report, err := api.SLAGetSLI("4", from, time.Time{}, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Periods) != 2 || !report.Periods[0].From.Equal(from) || report.Periods[1].To.Unix() != 1704067200 {
+		t.Fatalf("unexpected periods %+v", report.Periods)
+	}
+	values := report.Service("3")
+	if len(values) != 2 {
+		t.Fatalf("unexpected values %+v", values)
+	}
+	if v := values[0]; v.Downtime != time.Hour || v.Uptime != 719*time.Hour || v.SLI != 99.86 || v.ErrorBudget != -1008*time.Second {
+		t.Errorf("unexpected SLI %+v", v)
+	}
+	if d := report.SLI[1][0].ExcludedDowntimes; len(d) != 1 || d[0].Name != "Upgrade" {
+		t.Errorf("unexpected excluded downtimes %+v", d)
+	}
+	if report.Service("5") != nil {
+		t.Error("expected no values for unknown service")
+	}
+}
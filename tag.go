@@ -0,0 +1,20 @@
+package zabbix
+
+// Tag of host, item, trigger and other objects (Zabbix 4.2+ for most objects).
+type Tag struct {
+	Tag       string `json:"tag"`
+	Value     string `json:"value"`
+	Automatic int    `json:"automatic,omitempty" zabbix:"readonly"` // 1 for tags set by discovery
+}
+
+type Tags []Tag
+
+// Returns value of the first tag with given name and true, or "" and false if there is no such tag.
+func (tags Tags) Get(name string) (value string, ok bool) {
+	for _, t := range tags {
+		if t.Tag == name {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
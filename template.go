@@ -0,0 +1,33 @@
+package zabbix
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/template/object
+type Template struct {
+	TemplateId  string `json:"templateid,omitempty"`
+	Host        string `json:"host"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Templates []Template
+
+func (t *Template) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "template", IdField: "templateid"}
+}
+func (t *Template) ObjectId() string      { return t.TemplateId }
+func (t *Template) SetObjectId(id string) { t.TemplateId = id }
+
+type TemplateId struct {
+	TemplateId string `json:"templateid"`
+}
+
+type TemplateIds []TemplateId
+
+// Wrapper for template.get: https://www.zabbix.com/documentation/current/manual/api/reference/template/get
+func (api *API) TemplatesGet(params Params) (res Templates, err error) {
+	return GetObjects[Template](api, params)
+}
+
+// Gets template by Id only if there is exactly 1 matching template.
+func (api *API) TemplateGetById(id string) (res *Template, err error) {
+	return GetObject[Template](api, Params{"templateids": id})
+}
@@ -0,0 +1,197 @@
+package zabbix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	TokenStatus int
+)
+
+const (
+	TokenEnabled  TokenStatus = 0
+	TokenDisabled TokenStatus = 1
+)
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/token/object
+// Secret auth string of token is returned only by token.generate, see TokensGenerate.
+type Token struct {
+	TokenId     string      `json:"tokenid,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	UserId      string      `json:"userid,omitempty"` // default is current user
+	Status      TokenStatus `json:"status,omitempty"`
+	ExpiresAt   int64       `json:"expires_at,omitempty"` // Unix time, 0 if token never expires
+	LastAccess  int64       `json:"lastaccess,omitempty" zabbix:"readonly"`
+	CreatedAt   int64       `json:"created_at,omitempty" zabbix:"readonly"`
+	CreatorId   string      `json:"creator_userid,omitempty" zabbix:"readonly"`
+}
+
+type Tokens []Token
+
+func (t *Token) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "token", IdField: "tokenid", Requires: FeatureTokens}
+}
+func (t *Token) ObjectId() string      { return t.TokenId }
+func (t *Token) SetObjectId(id string) { t.TokenId = id }
+
+// Sets expiration time, zero time means token never expires.
+func (t *Token) SetExpires(expires time.Time) {
+	t.ExpiresAt = 0
+	if !expires.IsZero() {
+		t.ExpiresAt = expires.Unix()
+	}
+}
+
+// Returns expiration time, or zero time if token never expires.
+func (t *Token) Expires() time.Time {
+	if t.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t.ExpiresAt, 0)
+}
+
+// Returns true if token expires before or at now.
+func (t *Token) Expired(now time.Time) bool {
+	return t.ExpiresAt != 0 && now.Unix() >= t.ExpiresAt
+}
+
+// Wrapper for token.get: https://www.zabbix.com/documentation/current/manual/api/reference/token/get
+func (api *API) TokensGet(params Params) (res Tokens, err error) {
+	return GetObjects[Token](api, params)
+}
+
+// Gets token by Id only if there is exactly 1 matching token.
+func (api *API) TokenGetById(id string) (res *Token, err error) {
+	return GetObject[Token](api, Params{"tokenids": id})
+}
+
+// Wrapper for token.create: https://www.zabbix.com/documentation/current/manual/api/reference/token/create
+// Call TokensGenerate to get auth strings of created tokens.
+func (api *API) TokensCreate(tokens Tokens) (err error) {
+	return CreateObjects[Token](api, tokens)
+}
+
+// Wrapper for token.update: https://www.zabbix.com/documentation/current/manual/api/reference/token/update
+func (api *API) TokensUpdate(tokens Tokens, fields ...string) (err error) {
+	return UpdateObjects[Token](api, tokens, fields...)
+}
+
+// Wrapper for token.delete: https://www.zabbix.com/documentation/current/manual/api/reference/token/delete
+// Cleans TokenId in all tokens elements if call succeed.
+func (api *API) TokensDelete(tokens Tokens) (err error) {
+	return DeleteObjects[Token](api, tokens)
+}
+
+// Wrapper for token.delete: https://www.zabbix.com/documentation/current/manual/api/reference/token/delete
+func (api *API) TokensDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[Token](api, ids)
+}
+
+// Wrapper for token.generate: https://www.zabbix.com/documentation/current/manual/api/reference/token/generate
+// Returns auth strings by token Id. Previously generated auth strings of those tokens stop working.
+func (api *API) TokensGenerate(ids []string) (res map[string]string, err error) {
+	if err = api.require(FeatureTokens); err != nil {
+		return
+	}
+	var generated []struct {
+		TokenId string `json:"tokenid"`
+		Token   string `json:"token"`
+	}
+	if err = api.callResult("token.generate", ids, &generated); err != nil {
+		return
+	}
+	if len(generated) != len(ids) {
+		return nil, &ExpectedMore{len(ids), len(generated)}
+	}
+	res = make(map[string]string, len(generated))
+	for _, g := range generated {
+		res[g.TokenId] = g.Token
+	}
+	return
+}
+
+// Generates new auth string of token, invalidating the previous one.
+func (api *API) TokenRotate(id string) (auth string, err error) {
+	res, err := api.TokensGenerate([]string{id})
+	if err != nil {
+		return
+	}
+	auth, ok := res[id]
+	if !ok {
+		err = newDecodeError("token.generate", nil, fmt.Errorf("no token %s in result", id))
+	}
+	return
+}
+
+// Logs in with user and password, creates token for this user, switches api to it with SetAuth
+// and logs session out. Token fields Name (unique for user) and ExpiresAt should be set by caller,
+// TokenId is set if call succeed. Returns auth string of token to be stored instead of password.
+// On failure created token is deleted and api.Auth is reset; errors of these calls are reported with *CleanupError.
+func (api *API) LoginToken(user, password string, token *Token) (auth string, err error) {
+	if err = api.require(FeatureTokens); err != nil {
+		return
+	}
+	if _, err = api.Login(user, password); err != nil {
+		return
+	}
+
+	tokens := Tokens{*token}
+	if err = api.TokensCreate(tokens); err != nil {
+		return "", api.loginTokenCleanup(err, nil, true)
+	}
+	id := tokens[0].TokenId
+	if auth, err = api.TokenRotate(id); err != nil {
+		return "", api.loginTokenCleanup(err, tokens, true)
+	}
+	if err = api.Logout(); err != nil {
+		return "", api.loginTokenCleanup(err, tokens, true)
+	}
+	// session is closed, token deletes itself if SetAuth fails
+	if err = api.SetAuth(auth); err != nil {
+		return "", api.loginTokenCleanup(err, tokens, false)
+	}
+	token.TokenId = id
+	return
+}
+
+// CleanupError is returned when an operation failed with Err and undoing its effects failed too.
+type CleanupError struct {
+	Err     error
+	Cleanup []error
+}
+
+func (e *CleanupError) Error() string {
+	msg := e.Err.Error() + "; cleanup failed:"
+	for _, c := range e.Cleanup {
+		msg += " " + c.Error() + ";"
+	}
+	return strings.TrimSuffix(msg, ";")
+}
+
+func (e *CleanupError) Unwrap() error {
+	return e.Err
+}
+
+// Deletes tokens created by LoginToken, logs session out if logout is set and resets api.Auth.
+// Returns err, or *CleanupError with err if some of these calls failed.
+func (api *API) loginTokenCleanup(err error, tokens Tokens, logout bool) error {
+	var cleanup []error
+	if len(tokens) > 0 {
+		if e := api.TokensDelete(tokens); e != nil {
+			cleanup = append(cleanup, fmt.Errorf("delete token: %w", e))
+		}
+	}
+	if logout {
+		if e := api.Logout(); e != nil {
+			cleanup = append(cleanup, fmt.Errorf("logout: %w", e))
+		}
+	}
+	api.Auth = ""
+	if len(cleanup) == 0 {
+		return err
+	}
+	return &CleanupError{Err: err, Cleanup: cleanup}
+}
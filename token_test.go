@@ -0,0 +1,112 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestLoginToken(t *testing.T) {
+	var calls []string
+	record := func(method string, result interface{}) fakeHandler {
+		return func(raw json.RawMessage) interface{} {
+			calls = append(calls, method)
+			return result
+		}
+	}
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"user.login": record("user.login", "session"),
+		"token.create": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "token.create")
+			var p []map[string]interface{}
+			if err := json.Unmarshal(raw, &p); err != nil {
+				t.Error(err)
+				return nil
+			}
+			if p[0]["name"] != "deployer" || p[0]["expires_at"] != float64(1700000000) {
+				t.Errorf("unexpected params %s", raw)
+			}
+			return map[string]interface{}{"tokenids": []string{"4"}}
+		},
+		"token.generate": record("token.generate", []map[string]string{{"tokenid": "4", "token": "secret"}}),
+		"user.logout":    record("user.logout", true),
+	})
+
+	token := &Token{Name: "deployer"}
+	token.SetExpires(time.Unix(1700000000, 0))
+	auth, err := api.LoginToken("Admin", "zabbix", token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth != "secret" || api.Auth != "secret" || token.TokenId != "4" {
+		t.Errorf("unexpected auth %q, api.Auth %q, token %+v", auth, api.Auth, token)
+	}
+	expected := []string{"user.login", "token.create", "token.generate", "user.logout"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestLoginTokenCleanup(t *testing.T) {
+	var calls []string
+	api := newFakeAPI(t, "6.4.0", map[string]fakeHandler{
+		"user.login": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "user.login")
+			return "session"
+		},
+		"token.create": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "token.create")
+			return map[string]interface{}{"tokenids": []string{"4"}}
+		},
+		"token.generate": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "token.generate")
+			return []map[string]string{{"tokenid": "4", "token": "secret"}}
+		},
+		"token.delete": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "token.delete")
+			return map[string]interface{}{"tokenids": []string{"4"}}
+		},
+		"user.logout": func(raw json.RawMessage) interface{} {
+			calls = append(calls, "user.logout")
+			return &Error{Code: -32500, Message: "Application error.", Data: "Session terminated."}
+		},
+	})
+
+	token := &Token{Name: "deployer"}
+	auth, err := api.LoginToken("Admin", "zabbix", token)
+	var cleanupErr *CleanupError
+	if !errors.As(err, &cleanupErr) || len(cleanupErr.Cleanup) != 1 || !strings.Contains(err.Error(), "logout: ") {
+		t.Fatalf("expected *CleanupError with logout error, got %v", err)
+	}
+	if auth != "" || api.Auth != "" || token.TokenId != "" {
+		t.Errorf("unexpected auth %q, api.Auth %q, token %+v", auth, api.Auth, token)
+	}
+	expected := []string{"user.login", "token.create", "token.generate", "user.logout", "token.delete", "user.logout"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	now := time.Now()
+	var token Token
+	if token.Expired(now) || !token.Expires().IsZero() {
+		t.Error("token without expiration expired")
+	}
+	token.SetExpires(now.Add(-time.Second))
+	if !token.Expired(now) {
+		t.Error("token not expired")
+	}
+}
+
+func TestTokensUnsupported(t *testing.T) {
+	api := newFakeAPI(t, "5.2.0", nil)
+	if _, err := api.TokensGenerate([]string{"1"}); !errors.Is(err, ErrUnsupportedInVersion) {
+		t.Errorf("expected ErrUnsupportedInVersion, got %v", err)
+	}
+}
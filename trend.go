@@ -0,0 +1,34 @@
+package zabbix
+
+import "time"
+
+// Hourly aggregate of numeric item values.
+type Trend struct {
+	ItemId   string  `json:"itemid"`
+	Clock    int64   `json:"clock"` // start of hour
+	Num      int     `json:"num"`   // number of values
+	ValueMin float64 `json:"value_min"`
+	ValueAvg float64 `json:"value_avg"`
+	ValueMax float64 `json:"value_max"`
+}
+
+type Trends []Trend
+
+// Returns start of trend hour.
+func (t *Trend) Time() time.Time {
+	return time.Unix(t.Clock, 0)
+}
+
+// Wrapper for trend.get: https://www.zabbix.com/documentation/current/manual/api/reference/trend/get
+// Trends exist only for Float and Unsigned items. "output" defaults to "extend".
+func (api *API) TrendsGet(params Params) (res Trends, err error) {
+	p := make(Params, len(params)+1)
+	for k, v := range params {
+		p[k] = v
+	}
+	if _, present := p["output"]; !present {
+		p["output"] = "extend"
+	}
+	err = api.callResult("trend.get", p, &res)
+	return
+}
@@ -0,0 +1,160 @@
+package zabbix
+
+import "encoding/json"
+
+type (
+	UserType    int
+	MediaStatus int
+)
+
+const (
+	UserTypeUser       UserType = 1
+	UserTypeAdmin      UserType = 2
+	UserTypeSuperAdmin UserType = 3
+)
+
+const (
+	MediaEnabled  MediaStatus = 0
+	MediaDisabled MediaStatus = 1
+)
+
+// Severity mask of media: 1 - not classified, 2 - information, 4 - warning,
+// 8 - average, 16 - high, 32 - disaster.
+const AllSeverities = 63
+
+// Recipients of media. Single recipient is sent as string, several as array (email media types only).
+type MediaSendTo []string
+
+func (s MediaSendTo) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+// Accepts both string and array of strings.
+func (s *MediaSendTo) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*s = MediaSendTo{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*s = many
+	return nil
+}
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/user/object#media
+type Media struct {
+	MediaId     string      `json:"mediaid,omitempty" zabbix:"readonly"`
+	MediaTypeId string      `json:"mediatypeid"`
+	SendTo      MediaSendTo `json:"sendto"`
+	Active      MediaStatus `json:"active"`
+	Severity    int         `json:"severity,omitempty"` // default is AllSeverities
+	Period      string      `json:"period,omitempty"`   // default is "1-7,00:00-24:00"
+}
+
+type Medias []Media
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/user/object
+type User struct {
+	UserId      string       `json:"userid,omitempty"`
+	Username    string       `json:"username"`         // "alias" before Zabbix 5.4
+	Passwd      string       `json:"passwd,omitempty"` // write only
+	RoleId      string       `json:"roleid,omitempty"` // Zabbix 5.2+
+	Type        UserType     `json:"type,omitempty"`   // before Zabbix 5.2
+	Name        string       `json:"name,omitempty"`
+	Surname     string       `json:"surname,omitempty"`
+	Url         string       `json:"url,omitempty"`
+	Lang        string       `json:"lang,omitempty"`
+	Theme       string       `json:"theme,omitempty"`
+	Timezone    string       `json:"timezone,omitempty"` // Zabbix 5.2+
+	Autologin   int          `json:"autologin,omitempty"`
+	Autologout  string       `json:"autologout,omitempty"`
+	Refresh     string       `json:"refresh,omitempty"`
+	RowsPerPage int          `json:"rows_per_page,omitempty"`
+	UserGroups  UserGroupIds `json:"usrgrps,omitempty"` // use "selectUsrgrps" to get them
+	Medias      Medias       `json:"medias,omitempty"`  // use "selectMedias" to get them
+
+	AttemptFailed int    `json:"attempt_failed,omitempty" zabbix:"readonly"`
+	AttemptClock  int64  `json:"attempt_clock,omitempty" zabbix:"readonly"`
+	AttemptIp     string `json:"attempt_ip,omitempty" zabbix:"readonly"`
+}
+
+type Users []User
+
+type UserId struct {
+	UserId string `json:"userid"`
+}
+
+type UserIds []UserId
+
+// Decodes user across Zabbix versions: fills Username from "alias".
+func (u *User) UnmarshalJSON(b []byte) error {
+	type user User
+	var v struct {
+		user
+		Alias string `json:"alias"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*u = User(v.user)
+	if u.Username == "" {
+		u.Username = v.Alias
+	}
+	return nil
+}
+
+func (u *User) ObjectInfo() ObjectInfo { return ObjectInfo{Prefix: "user", IdField: "userid"} }
+func (u *User) ObjectId() string       { return u.UserId }
+func (u *User) SetObjectId(id string)  { u.UserId = id }
+
+// Wrapper for user.get: https://www.zabbix.com/documentation/current/manual/api/reference/user/get
+func (api *API) UsersGet(params Params) (res Users, err error) {
+	return GetObjects[User](api, params)
+}
+
+// Gets user by Id only if there is exactly 1 matching user. User groups and medias are selected.
+func (api *API) UserGetById(id string) (res *User, err error) {
+	return GetObject[User](api, Params{"userids": id, "selectUsrgrps": []string{"usrgrpid"}, "selectMedias": "extend"})
+}
+
+// Gets user by username only if there is exactly 1 matching user.
+func (api *API) UserGetByUsername(username string) (res *User, err error) {
+	v, err := api.version()
+	if err != nil {
+		return
+	}
+	key := "username"
+	if !v.Supports(FeatureUserUsername) {
+		key = "alias"
+	}
+	return GetObject[User](api, Params{"filter": map[string]string{key: username}})
+}
+
+// Wrapper for user.create: https://www.zabbix.com/documentation/current/manual/api/reference/user/create
+func (api *API) UsersCreate(users Users) (err error) {
+	return CreateObjects[User](api, users)
+}
+
+// Wrapper for user.update: https://www.zabbix.com/documentation/current/manual/api/reference/user/update
+// Non-empty UserGroups and Medias replace existing ones.
+func (api *API) UsersUpdate(users Users, fields ...string) (err error) {
+	return UpdateObjects[User](api, users, fields...)
+}
+
+// Wrapper for user.delete: https://www.zabbix.com/documentation/current/manual/api/reference/user/delete
+// Cleans UserId in all users elements if call succeed.
+func (api *API) UsersDelete(users Users) (err error) {
+	return DeleteObjects[User](api, users)
+}
+
+// Wrapper for user.delete: https://www.zabbix.com/documentation/current/manual/api/reference/user/delete
+func (api *API) UsersDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[User](api, ids)
+}
@@ -0,0 +1,125 @@
+package zabbix
+
+type (
+	GUIAccess   int
+	UsersStatus int
+	Permission  int
+)
+
+const (
+	GUIAccessDefault  GUIAccess = 0
+	GUIAccessInternal GUIAccess = 1
+	GUIAccessLDAP     GUIAccess = 2
+	GUIAccessDisabled GUIAccess = 3
+)
+
+const (
+	UsersEnabled  UsersStatus = 0
+	UsersDisabled UsersStatus = 1
+)
+
+const (
+	PermissionDenied    Permission = 0
+	PermissionRead      Permission = 2
+	PermissionReadWrite Permission = 3
+)
+
+// Permission of user group to host group or template group with Id.
+type Right struct {
+	Id         string     `json:"id"`
+	Permission Permission `json:"permission"`
+}
+
+type Rights []Right
+
+// Tag based permission of user group to host group (Zabbix 4.0+). Empty tag allows all problems.
+type TagFilter struct {
+	GroupId string `json:"groupid"`
+	Tag     string `json:"tag,omitempty"`
+	Value   string `json:"value,omitempty"`
+}
+
+type TagFilters []TagFilter
+
+// https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/object
+type UserGroup struct {
+	UserGroupId string      `json:"usrgrpid,omitempty"`
+	Name        string      `json:"name"`
+	GUIAccess   GUIAccess   `json:"gui_access,omitempty"`
+	UsersStatus UsersStatus `json:"users_status,omitempty"`
+	DebugMode   int         `json:"debug_mode,omitempty"`
+
+	// Use "selectHostGroupRights", "selectTemplateGroupRights" and "selectTagFilters" to get them.
+	HostGroupRights     Rights     `json:"hostgroup_rights,omitempty"`     // "rights" before Zabbix 6.2
+	TemplateGroupRights Rights     `json:"templategroup_rights,omitempty"` // Zabbix 6.2+
+	TagFilters          TagFilters `json:"tag_filters,omitempty"`
+}
+
+type UserGroups []UserGroup
+
+// Decodes user group across Zabbix versions: fills HostGroupRights from "rights".
+func (g *UserGroup) UnmarshalJSON(b []byte) error {
+	type userGroup UserGroup
+	var v struct {
+		userGroup
+		Rights Rights `json:"rights"`
+	}
+	if err := unmarshalLenient(b, &v); err != nil {
+		return err
+	}
+
+	*g = UserGroup(v.userGroup)
+	if g.HostGroupRights == nil {
+		g.HostGroupRights = v.Rights
+	}
+	return nil
+}
+
+func (g *UserGroup) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Prefix: "usergroup", IdField: "usrgrpid"}
+}
+func (g *UserGroup) ObjectId() string      { return g.UserGroupId }
+func (g *UserGroup) SetObjectId(id string) { g.UserGroupId = id }
+
+type UserGroupId struct {
+	UserGroupId string `json:"usrgrpid"`
+}
+
+type UserGroupIds []UserGroupId
+
+// Wrapper for usergroup.get: https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/get
+func (api *API) UserGroupsGet(params Params) (res UserGroups, err error) {
+	return GetObjects[UserGroup](api, params)
+}
+
+// Gets user group by Id only if there is exactly 1 matching user group. Rights and tag filters are selected.
+func (api *API) UserGroupGetById(id string) (res *UserGroup, err error) {
+	return GetObject[UserGroup](api, Params{
+		"usrgrpids":                 id,
+		"selectHostGroupRights":     "extend",
+		"selectTemplateGroupRights": "extend",
+		"selectTagFilters":          "extend",
+	})
+}
+
+// Wrapper for usergroup.create: https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/create
+func (api *API) UserGroupsCreate(groups UserGroups) (err error) {
+	return CreateObjects[UserGroup](api, groups)
+}
+
+// Wrapper for usergroup.update: https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/update
+// Non-empty rights and tag filters replace existing ones.
+func (api *API) UserGroupsUpdate(groups UserGroups, fields ...string) (err error) {
+	return UpdateObjects[UserGroup](api, groups, fields...)
+}
+
+// Wrapper for usergroup.delete: https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/delete
+// Cleans UserGroupId in all groups elements if call succeed.
+func (api *API) UserGroupsDelete(groups UserGroups) (err error) {
+	return DeleteObjects[UserGroup](api, groups)
+}
+
+// Wrapper for usergroup.delete: https://www.zabbix.com/documentation/current/manual/api/reference/usergroup/delete
+func (api *API) UserGroupsDeleteByIds(ids []string) (err error) {
+	return DeleteObjectsByIds[UserGroup](api, ids)
+}
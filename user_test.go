@@ -0,0 +1,160 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/canghai908/zabbix-go"
+)
+
+func TestUserCreateVersions(t *testing.T) {
+	for version, c := range map[string]struct {
+		present, absent []string
+	}{
+		"5.0.0": {[]string{"alias", "type", "user_medias"}, []string{"username", "roleid", "medias"}},
+		"5.2.0": {[]string{"alias", "roleid", "medias"}, []string{"username", "type", "user_medias"}},
+		"6.4.0": {[]string{"username", "roleid", "medias"}, []string{"alias", "type", "user_medias"}},
+	} {
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"user.create": func(raw json.RawMessage) interface{} {
+				var p []map[string]interface{}
+				if err := json.Unmarshal(raw, &p); err != nil {
+					t.Error(err)
+					return nil
+				}
+				for _, key := range c.present {
+					if _, present := p[0][key]; !present {
+						t.Errorf("%s: expected %q in %s", version, key, raw)
+					}
+				}
+				for _, key := range c.absent {
+					if _, present := p[0][key]; present {
+						t.Errorf("%s: unexpected %q in %s", version, key, raw)
+					}
+				}
+				return map[string]interface{}{"userids": []string{"7"}}
+			},
+		})
+
+		users := Users{{
+			Username:   "jdoe",
+			Passwd:     "secret",
+			RoleId:     "1",
+			Type:       UserTypeUser,
+			UserGroups: UserGroupIds{{UserGroupId: "8"}},
+			Medias:     Medias{{MediaTypeId: "1", SendTo: MediaSendTo{"jdoe@example.com"}}},
+		}}
+		if err := api.UsersCreate(users); err != nil {
+			t.Fatal(err)
+		}
+		if users[0].UserId != "7" {
+			t.Errorf("unexpected Id %q", users[0].UserId)
+		}
+	}
+}
+
+func TestUserDecode(t *testing.T) {
+	api := newFakeAPI(t, "5.0.0", map[string]fakeHandler{
+		"user.get": func(raw json.RawMessage) interface{} {
+			p := decodeParams(t, raw)
+			if p["filter"].(map[string]interface{})["alias"] != "jdoe" {
+				t.Errorf("expected alias filter in %s", raw)
+			}
+			return []map[string]interface{}{{
+				"userid": "7", "alias": "jdoe", "type": "3", "attempt_clock": "0",
+				"medias": []map[string]interface{}{
+					{"mediaid": "1", "mediatypeid": "1", "sendto": []string{"a@example.com", "b@example.com"}, "active": "0", "severity": "63"},
+					{"mediaid": "2", "mediatypeid": "3", "sendto": "+100", "active": "1", "severity": "48"},
+				},
+			}}
+		},
+	})
+
+	u, err := api.UserGetByUsername("jdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &User{UserId: "7", Username: "jdoe", Type: UserTypeSuperAdmin, Medias: Medias{
+		{MediaId: "1", MediaTypeId: "1", SendTo: MediaSendTo{"a@example.com", "b@example.com"}, Severity: AllSeverities},
+		{MediaId: "2", MediaTypeId: "3", SendTo: MediaSendTo{"+100"}, Active: MediaDisabled, Severity: 48},
+	}}
+	if !reflect.DeepEqual(u, expected) {
+		t.Errorf("expected %+v, got %+v", expected, u)
+	}
+}
+
+func TestUserGroupRightsVersions(t *testing.T) {
+	for _, version := range []string{"6.0.0", "6.4.0"} {
+		var created map[string]interface{}
+		api := newFakeAPI(t, version, map[string]fakeHandler{
+			"usergroup.create": func(raw json.RawMessage) interface{} {
+				var p []map[string]interface{}
+				if err := json.Unmarshal(raw, &p); err != nil {
+					t.Error(err)
+					return nil
+				}
+				created = p[0]
+				return map[string]interface{}{"usrgrpids": []string{"9"}}
+			},
+			"usergroup.get": func(raw json.RawMessage) interface{} {
+				key := "hostgroup_rights"
+				if version == "6.0.0" {
+					key = "rights"
+				}
+				return []map[string]interface{}{{
+					"usrgrpid": "9", "name": "ops", key: []map[string]string{{"id": "2", "permission": "3"}},
+				}}
+			},
+		})
+
+		g := UserGroups{{
+			Name:                "ops",
+			HostGroupRights:     Rights{{Id: "2", Permission: PermissionReadWrite}},
+			TemplateGroupRights: Rights{{Id: "5", Permission: PermissionRead}},
+			TagFilters:          TagFilters{{GroupId: "2", Tag: "service"}},
+		}}
+		if err := api.UserGroupsCreate(g); err != nil {
+			t.Fatal(err)
+		}
+		_, hasRights := created["rights"]
+		_, hasTemplateRights := created["templategroup_rights"]
+		if version == "6.0.0" && (!hasRights || hasTemplateRights) || version == "6.4.0" && hasRights {
+			t.Errorf("%s: unexpected params %v", version, created)
+		}
+
+		res, err := api.UserGroupGetById("9")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(res.HostGroupRights, Rights{{Id: "2", Permission: PermissionReadWrite}}) {
+			t.Errorf("%s: unexpected rights %+v", version, res.HostGroupRights)
+		}
+	}
+}
+
+func TestRolesUnsupported(t *testing.T) {
+	api := newFakeAPI(t, "5.0.0", nil)
+	if _, err := api.RolesGet(Params{}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestRoleCreateRules(t *testing.T) {
+	expected := `[{"name":"Viewer","rules":{"api.mode":0,"ui":[{"name":"monitoring.hosts","status":1}]},"type":1}]`
+	api := newFakeAPI(t, "6.0.0", map[string]fakeHandler{
+		"role.create": func(raw json.RawMessage) interface{} {
+			if string(raw) != expected {
+				t.Errorf("expected\n%s\ngot\n%s", expected, raw)
+			}
+			return map[string]interface{}{"roleids": []string{"5"}}
+		},
+	})
+	roles := Roles{{Name: "Viewer", Type: UserTypeUser, Rules: &RoleRules{UI: RoleRuleList{{Name: "monitoring.hosts", Status: RuleEnabled}}}}}
+	if err := api.RolesCreate(roles); err != nil {
+		t.Fatal(err)
+	}
+	if roles[0].RoleId != "5" {
+		t.Errorf("unexpected Id %q", roles[0].RoleId)
+	}
+}
@@ -0,0 +1,144 @@
+package zabbix
+
+import "strconv"
+
+// Typed configuration of dashboard widget, see NewWidget.
+type WidgetConfig interface {
+	WidgetType() string
+	WidgetFields() WidgetFields
+}
+
+// Returns widget of config type with its fields placed at x, y.
+func NewWidget(config WidgetConfig, x, y, width, height int) Widget {
+	return Widget{Type: config.WidgetType(), X: x, Y: y, Width: width, Height: height, Fields: config.WidgetFields()}
+}
+
+func intField(name string, v int) WidgetField {
+	return WidgetField{Type: WidgetFieldInt, Name: name, Value: strconv.Itoa(v)}
+}
+
+func boolField(name string, v bool) WidgetField {
+	if v {
+		return intField(name, 1)
+	}
+	return intField(name, 0)
+}
+
+func idFields(t WidgetFieldType, name string, ids []string) (res WidgetFields) {
+	for _, id := range ids {
+		res = append(res, WidgetField{Type: t, Name: name, Value: id})
+	}
+	return
+}
+
+// Widget with graph or simple graph of single item.
+type GraphWidget struct {
+	GraphId    string // set either GraphId or ItemId
+	ItemId     string
+	ShowLegend bool
+}
+
+func (w *GraphWidget) WidgetType() string { return "graph" }
+func (w *GraphWidget) WidgetFields() (res WidgetFields) {
+	if w.ItemId != "" {
+		res = append(res, intField("source_type", 1), WidgetField{Type: WidgetFieldItem, Name: "itemid", Value: w.ItemId})
+	} else {
+		res = append(res, intField("source_type", 0), WidgetField{Type: WidgetFieldGraph, Name: "graphid", Value: w.GraphId})
+	}
+	return append(res, boolField("show_legend", w.ShowLegend))
+}
+
+// Widget with list of current problems.
+type ProblemsWidget struct {
+	GroupIds   []string
+	HostIds    []string
+	Severities []int // 0 - not classified ... 5 - disaster, all if empty
+	ShowLines  int   // default is 25
+	ShowTags   int   // number of tags to show, 0 - none
+}
+
+func (w *ProblemsWidget) WidgetType() string { return "problems" }
+func (w *ProblemsWidget) WidgetFields() (res WidgetFields) {
+	res = append(res, idFields(WidgetFieldHostGroup, "groupids", w.GroupIds)...)
+	res = append(res, idFields(WidgetFieldHost, "hostids", w.HostIds)...)
+	for _, s := range w.Severities {
+		res = append(res, intField("severities", s))
+	}
+	if w.ShowLines > 0 {
+		res = append(res, intField("show_lines", w.ShowLines))
+	}
+	if w.ShowTags > 0 {
+		res = append(res, intField("show_tags", w.ShowTags))
+	}
+	return
+}
+
+// Widget with latest value of single item.
+type ItemValueWidget struct {
+	ItemId      string
+	Description string // default is "{ITEM.NAME}"
+	Decimals    int
+}
+
+func (w *ItemValueWidget) WidgetType() string { return "item" }
+func (w *ItemValueWidget) WidgetFields() (res WidgetFields) {
+	res = WidgetFields{{Type: WidgetFieldItem, Name: "itemid", Value: w.ItemId}}
+	if w.Description != "" {
+		res = append(res, WidgetField{Type: WidgetFieldString, Name: "description", Value: w.Description})
+	}
+	if w.Decimals > 0 {
+		res = append(res, intField("decimal_places", w.Decimals))
+	}
+	return
+}
+
+// Column of TopHostsWidget with latest value of item.
+type TopHostsColumn struct {
+	Name string
+	Item string // item name
+}
+
+// Widget with hosts ordered by value of item column.
+type TopHostsWidget struct {
+	GroupIds    []string
+	HostIds     []string
+	Columns     []TopHostsColumn
+	OrderColumn int // index in Columns
+	Count       int // number of hosts, default is 10
+}
+
+func (w *TopHostsWidget) WidgetType() string { return "tophosts" }
+func (w *TopHostsWidget) WidgetFields() (res WidgetFields) {
+	res = append(res, idFields(WidgetFieldHostGroup, "groupids", w.GroupIds)...)
+	res = append(res, idFields(WidgetFieldHost, "hostids", w.HostIds)...)
+	for i, c := range w.Columns {
+		prefix := "columns." + strconv.Itoa(i) + "."
+		res = append(res,
+			WidgetField{Type: WidgetFieldString, Name: prefix + "name", Value: c.Name},
+			intField(prefix+"data", 1), // item value
+			WidgetField{Type: WidgetFieldString, Name: prefix + "item", Value: c.Item},
+		)
+	}
+	res = append(res, intField("column", w.OrderColumn))
+	if w.Count > 0 {
+		res = append(res, intField("count", w.Count))
+	}
+	return
+}
+
+// Widget with honeycomb of item values of hosts (Zabbix 7.0+).
+type HoneycombWidget struct {
+	GroupIds []string
+	HostIds  []string
+	Items    []string // item name patterns
+}
+
+func (w *HoneycombWidget) WidgetType() string { return "honeycomb" }
+func (w *HoneycombWidget) WidgetFields() (res WidgetFields) {
+	res = append(res, idFields(WidgetFieldHostGroup, "groupids", w.GroupIds)...)
+	res = append(res, idFields(WidgetFieldHost, "hostids", w.HostIds)...)
+	for i, item := range w.Items {
+		res = append(res, WidgetField{Type: WidgetFieldString, Name: "items." + strconv.Itoa(i), Value: item})
+	}
+	return
+}
@@ -0,0 +1,169 @@
+package zabbix
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// ZBXD header flags: https://www.zabbix.com/documentation/current/manual/appendix/protocols/header_datalen
+const (
+	zbxdProtocol   = 0x01
+	zbxdCompressed = 0x02
+	zbxdLarge      = 0x04
+)
+
+const zbxdMaxSize = 1 << 30 // limit of data size accepted by Zabbix server
+
+// Returns ZBXD packet with data: header, flags, data length and reserved length of uncompressed data,
+// 4 bytes each. If compress is set data is compressed with zlib.
+func buildPacket(data []byte, compress bool) ([]byte, error) {
+	flags := byte(zbxdProtocol)
+	reserved := 0
+	if compress {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		flags |= zbxdCompressed
+		reserved = len(data)
+		data = buf.Bytes()
+	}
+
+	packet := make([]byte, 13, 13+len(data))
+	copy(packet, "ZBXD")
+	packet[4] = flags
+	binary.LittleEndian.PutUint32(packet[5:9], uint32(len(data)))
+	binary.LittleEndian.PutUint32(packet[9:13], uint32(reserved))
+	return append(packet, data...), nil
+}
+
+// Reads ZBXD packet and returns its data, decompressed if needed.
+func readPacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read response header: %w", err)
+	}
+	if string(header[:4]) != "ZBXD" || header[4]&zbxdProtocol == 0 {
+		return nil, fmt.Errorf("invalid response header %q", header)
+	}
+	flags := header[4]
+
+	var size, reserved uint64
+	if flags&zbxdLarge != 0 {
+		lengths := make([]byte, 16)
+		if _, err := io.ReadFull(r, lengths); err != nil {
+			return nil, fmt.Errorf("failed to read data length: %w", err)
+		}
+		size, reserved = binary.LittleEndian.Uint64(lengths[:8]), binary.LittleEndian.Uint64(lengths[8:])
+	} else {
+		lengths := make([]byte, 8)
+		if _, err := io.ReadFull(r, lengths); err != nil {
+			return nil, fmt.Errorf("failed to read data length: %w", err)
+		}
+		size, reserved = uint64(binary.LittleEndian.Uint32(lengths[:4])), uint64(binary.LittleEndian.Uint32(lengths[4:]))
+	}
+	if size > zbxdMaxSize || reserved > zbxdMaxSize {
+		return nil, fmt.Errorf("response of %d bytes is too large", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read response data: %w", err)
+	}
+	if flags&zbxdCompressed == 0 {
+		return data, nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	res := make([]byte, reserved)
+	if _, err = io.ReadFull(zr, res); err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return res, nil
+}
+
+// ProtocolError is returned when Zabbix server or proxy responds to protocol request with "failed".
+type ProtocolError struct {
+	Request string // e.g. "proxy data"
+	Info    string // reason given by server
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("%s request failed: %s", e.Request, e.Info)
+}
+
+// zbxdConn holds connection settings of JSON request-response exchanges over ZBXD protocol.
+type zbxdConn struct {
+	address   string
+	timeout   time.Duration
+	tlsConfig *tls.Config // nil for unencrypted connection
+	compress  bool
+	printf    func(format string, v ...interface{})
+}
+
+// Sends request encoded to JSON over new connection and decodes response to res.
+// Returns *ProtocolError if response is {"response": "failed"}.
+func (c *zbxdConn) exchange(request string, req, res interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", request, err)
+	}
+	packet, err := buildPacket(b, c.compress)
+	if err != nil {
+		return fmt.Errorf("failed to compress %s request: %w", request, err)
+	}
+	c.printf("Sending %s request: %s", request, b)
+
+	dialer := &net.Dialer{Timeout: c.timeout}
+	var conn net.Conn
+	if c.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.address, c.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", c.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.address, err)
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return fmt.Errorf("failed to set deadline: %w", err)
+	}
+	if _, err = conn.Write(packet); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", request, err)
+	}
+	data, err := readPacket(conn)
+	if err != nil {
+		return err
+	}
+	c.printf("Received response: %s", data)
+
+	var status struct {
+		Response string `json:"response"`
+		Info     string `json:"info"`
+	}
+	if err = json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if status.Response == "failed" {
+		return &ProtocolError{Request: request, Info: status.Info}
+	}
+	if err = json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}